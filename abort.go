@@ -0,0 +1,28 @@
+package goblin
+
+import (
+	"fmt"
+)
+
+// AbortSuite fails the running spec or hook and stops the run from scheduling
+// any more specs, for conditions nothing else can possibly pass after, such as
+// a failed database migration. The remaining specs are reported as skipped
+// with reason, while the After hooks of the blocks being run and the suite
+// teardown still run.
+func (g *G) AbortSuite(reason string) {
+	suite := g.suite()
+	suite.mutex.Lock()
+	if suite.aborted == "" {
+		suite.aborted = reason
+	}
+	suite.mutex.Unlock()
+	g.errorCommon(fmt.Sprintf("suite aborted: %s", reason), true)
+}
+
+// abortReason returns why the run was aborted, if it was
+func (g *G) abortReason() string {
+	g = g.suite()
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.aborted
+}
@@ -0,0 +1,60 @@
+package goblin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAbortSuite(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := skipReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var teardown, ran []string
+	g.Describe("Database", func() {
+		g.After(func() {
+			teardown = append(teardown, "database")
+		})
+		g.Describe("Migrations", func() {
+			g.It("Should migrate", func() {
+				ran = append(ran, "migrate")
+				g.AbortSuite("migration failed")
+				ran = append(ran, "after abort")
+			}, Retry(2))
+			g.It("Should seed", func() {
+				ran = append(ran, "seed")
+			})
+		})
+		g.Describe("Queries", func() {
+			g.Before(func() {
+				ran = append(ran, "before queries")
+			})
+			g.It("Should query", func() {
+				ran = append(ran, "query")
+			})
+			g.It("Should be pending")
+		})
+	})
+	g.Describe("Later", func() {
+		g.It("Should not run", func() {
+			ran = append(ran, "later")
+		})
+	})
+
+	if !fakeTest.Failed() || !reflect.DeepEqual(reporter.fails, []string{"Should migrate"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if !reflect.DeepEqual(ran, []string{"migrate"}) || !reflect.DeepEqual(teardown, []string{"database"}) {
+		t.Fatalf("Failed: ran %v, teardown %v", ran, teardown)
+	}
+	expected := []string{
+		"Should seed: suite aborted: migration failed",
+		"Should query: suite aborted: migration failed",
+		"Should not run: suite aborted: migration failed",
+	}
+	if !reflect.DeepEqual(reporter.skipped, expected) || !reflect.DeepEqual(reporter.pending, []string{"Should be pending"}) {
+		t.Fatalf("Failed: skipped %v, pending %v", reporter.skipped, reporter.pending)
+	}
+}
@@ -1,6 +1,7 @@
 package goblin
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -9,8 +10,9 @@ import (
 // Assertion represents a fact stated about a source object. It contains the
 // source object and function to call
 type Assertion struct {
-	src  interface{}
-	fail func(interface{})
+	src         interface{}
+	fail        func(interface{})
+	comparators *comparators // Registered for the suite, if any
 }
 
 func objectsAreEqual(a, b interface{}) bool {
@@ -61,10 +63,16 @@ func (a *Assertion) Eql(dst interface{}, messages ...interface{}) {
 
 // Equal takes a destination object and asserts that a source object and
 // destination object are equal to one another. It will fail the assertion and
-// print a corresponding message if the objects are not equivalent.
+// print a corresponding message if the objects are not equivalent. Structs,
+// maps, slices, arrays and multi-line strings are shown as a diff of the
+// expected and actual values.
 func (a *Assertion) Equal(dst interface{}, messages ...interface{}) {
-	if !objectsAreEqual(a.src, dst) {
-		a.fail(fmt.Sprintf("%#v %s %#v%s", a.src, "does not equal", dst,
+	if !a.equal(dst) {
+		if diff, ok := a.diff(dst); ok {
+			a.fail(fmt.Sprintf("%T does not equal expected%s\n%s", a.src, formatMessages(messages...), diff))
+			return
+		}
+		a.fail(fmt.Sprintf("%s %s %s%s", a.format(a.src), "does not equal", a.format(dst),
 			formatMessages(messages...)))
 	}
 }
@@ -151,3 +159,94 @@ func (a *Assertion) IsNotZero(messages ...interface{}) {
 		a.fail(message)
 	}
 }
+
+// IsError asserts that the source is an error which is, or wraps, target, as
+// reported by errors.Is.
+func (a *Assertion) IsError(target error, messages ...interface{}) {
+	err, ok := a.src.(error)
+	if a.src != nil && !ok {
+		a.fail(fmt.Sprintf("%#v %s%s", a.src, "is not an error", formatMessages(messages...)))
+		return
+	}
+	if !errors.Is(err, target) {
+		a.fail(fmt.Sprintf("%v %s %v%s", err, "does not match", target, formatMessages(messages...)))
+	}
+}
+
+// AsError asserts that the source is an error which is, or wraps, an error
+// assignable to target, which must be a non-nil pointer to an error type or
+// to an interface. As with errors.As, target is set to that error.
+func (a *Assertion) AsError(target interface{}, messages ...interface{}) {
+	err, ok := a.src.(error)
+	if a.src != nil && !ok {
+		a.fail(fmt.Sprintf("%#v %s%s", a.src, "is not an error", formatMessages(messages...)))
+		return
+	}
+	t := reflect.TypeOf(target)
+	if target == nil || t.Kind() != reflect.Ptr || reflect.ValueOf(target).IsNil() {
+		a.fail(fmt.Sprintf("%#v %s%s", target, "is not a non-nil pointer to set the error to", formatMessages(messages...)))
+		return
+	}
+	if elem := t.Elem(); elem.Kind() != reflect.Interface && !elem.Implements(reflect.TypeOf((*error)(nil)).Elem()) {
+		a.fail(fmt.Sprintf("%s %s%s", t, "does not point to an error type or an interface", formatMessages(messages...)))
+		return
+	}
+	if !errors.As(err, target) {
+		a.fail(fmt.Sprintf("%v %s %s%s", err, "does not match", t.Elem(), formatMessages(messages...)))
+	}
+}
+
+// Satisfies asserts that the source passes the given predicate. The
+// description is used to explain the condition in the displayed message if
+// the assertion fails, e.g. "an even number". Use Predicate to adapt a typed
+// predicate function.
+func (a *Assertion) Satisfies(predicate func(interface{}) bool, description string, messages ...interface{}) {
+	if !predicate(a.src) {
+		message := fmt.Sprintf("%#v %s %s%s", a.src, "does not satisfy", description, formatMessages(messages...))
+		a.fail(message)
+	}
+}
+
+// Predicate adapts a typed predicate so it can be used with Satisfies. The
+// resulting predicate is false for any source that is not of type T.
+func Predicate[T any](predicate func(T) bool) func(interface{}) bool {
+	return func(src interface{}) bool {
+		v, ok := src.(T)
+		if !ok {
+			return false
+		}
+		return predicate(v)
+	}
+}
+
+// Each asserts that every element of a source slice or array passes the given
+// check. The check receives an Assertion for each element; all failing
+// elements are reported together with their indexes and values rather than
+// stopping at the first one.
+func (a *Assertion) Each(check func(a *Assertion), messages ...interface{}) {
+	valueOf := reflect.ValueOf(a.src)
+	if valueOf.Kind() != reflect.Slice && valueOf.Kind() != reflect.Array {
+		a.fail(fmt.Sprintf("%#v %s%s", a.src, "is not a slice or array", formatMessages(messages...)))
+		return
+	}
+
+	var failures []string
+	for i := 0; i < valueOf.Len(); i++ {
+		elem := valueOf.Index(i).Interface()
+		var msg interface{}
+		check(&Assertion{src: elem, comparators: a.comparators, fail: func(m interface{}) {
+			// Only keep the first failure for each element
+			if msg == nil {
+				msg = m
+			}
+		}})
+		if msg != nil {
+			failures = append(failures, fmt.Sprintf("[%d] %#v: %v", i, elem, msg))
+		}
+	}
+
+	if len(failures) > 0 {
+		a.fail(fmt.Sprintf("%d of %d elements failed%s\n%s", len(failures), valueOf.Len(),
+			formatMessages(messages...), strings.Join(failures, "\n")))
+	}
+}
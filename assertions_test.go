@@ -2,7 +2,10 @@ package goblin
 
 import (
 	"fmt"
+	"io/fs"
+	"os"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -106,6 +109,51 @@ func TestEqualWithMessage(t *testing.T) {
 	verifier.VerifyMessage(t, "1 does not equal 0, "+msg)
 }
 
+type diffUser struct {
+	Name  string
+	Tags  []string
+	admin bool
+}
+
+// Test Equal() shows a diff of structs and multi-line strings upon failure.
+func TestEqualWithDiff(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: false}
+	a := Assertion{src: diffUser{Name: "ann", Tags: []string{"a", "b"}}, fail: verifier.FailFunc}
+	a.Equal(diffUser{Name: "ann", Tags: []string{"a", "c"}, admin: true}, "users")
+	verifier.VerifyMessage(t, "goblin.diffUser does not equal expected, users\n"+
+		"--- expected\n"+
+		"+++ actual\n"+
+		" goblin.diffUser{\n"+
+		"     Name: \"ann\",\n"+
+		"     Tags: []string{\n"+
+		"         \"a\",\n"+
+		"-        \"c\",\n"+
+		"+        \"b\",\n"+
+		"     },\n"+
+		"-    admin: true,\n"+
+		"+    admin: false,\n"+
+		" }")
+
+	lines := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: strings.Join(lines, "\n") + "\n11", fail: verifier.FailFunc}
+	a.Equal(strings.Join(lines, "\n"))
+	verifier.VerifyMessage(t, "string does not equal expected\n"+
+		"--- expected\n"+
+		"+++ actual\n"+
+		" ...\n"+
+		" 8\n"+
+		" 9\n"+
+		" 10\n"+
+		"+11")
+
+	// Single-line strings are shown as before
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: "foo", fail: verifier.FailFunc}
+	a.Equal("bar")
+	verifier.VerifyMessage(t, `"foo" does not equal "bar"`)
+}
+
 func TestIsTrue(t *testing.T) {
 	verifier := AssertionVerifier{ShouldPass: true}
 	a := Assertion{src: true, fail: verifier.FailFunc}
@@ -331,3 +379,109 @@ func TestIsNotZeroWithMessage(t *testing.T) {
 	message := "struct { Name string }{Name:\"\"} is a zero value, should not be zero"
 	verifier.VerifyMessage(t, message)
 }
+
+func TestSatisfies(t *testing.T) {
+	isEven := Predicate(func(i int) bool { return i%2 == 0 })
+
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: 2, fail: verifier.FailFunc}
+	a.Satisfies(isEven, "an even number")
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: 3, fail: verifier.FailFunc}
+	a.Satisfies(isEven, "an even number")
+	verifier.Verify(t)
+
+	// Sources of a different type never satisfy a typed predicate
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: "2", fail: verifier.FailFunc}
+	a.Satisfies(isEven, "an even number")
+	verifier.Verify(t)
+}
+
+func TestSatisfiesWithMessage(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: false}
+	a := Assertion{src: 3, fail: verifier.FailFunc}
+	a.Satisfies(func(interface{}) bool { return false }, "an even number", "odd")
+	verifier.VerifyMessage(t, "3 does not satisfy an even number, odd")
+}
+
+func TestEach(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: []int{2, 4, 6}, fail: verifier.FailFunc}
+	a.Each(func(e *Assertion) {
+		e.Satisfies(Predicate(func(i int) bool { return i%2 == 0 }), "an even number")
+	})
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: true}
+	a = Assertion{src: [2]string{"a", "a"}, fail: verifier.FailFunc}
+	a.Each(func(e *Assertion) { e.Equal("a") })
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: 1, fail: verifier.FailFunc}
+	a.Each(func(e *Assertion) {})
+	verifier.Verify(t)
+}
+
+func TestEachWithMessage(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: false}
+	a := Assertion{src: []int{1, 2, 3}, fail: verifier.FailFunc}
+	a.Each(func(e *Assertion) { e.Equal(2) }, "all twos")
+	verifier.VerifyMessage(t, "2 of 3 elements failed, all twos\n"+
+		"[0] 1: 1 does not equal 2\n"+
+		"[2] 3: 3 does not equal 2")
+}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return "code " + strconv.Itoa(e.code)
+}
+
+func TestIsError(t *testing.T) {
+	wrapped := fmt.Errorf("open config: %w", os.ErrNotExist)
+
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: wrapped, fail: verifier.FailFunc}
+	a.IsError(os.ErrNotExist)
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: wrapped, fail: verifier.FailFunc}
+	a.IsError(os.ErrPermission, "denied")
+	verifier.VerifyMessage(t, "open config: file does not exist does not match permission denied, denied")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: "not an error", fail: verifier.FailFunc}
+	a.IsError(os.ErrNotExist)
+	verifier.VerifyMessage(t, `"not an error" is not an error`)
+}
+
+func TestAsError(t *testing.T) {
+	wrapped := fmt.Errorf("request failed: %w", &codeError{404})
+
+	var target *codeError
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: wrapped, fail: verifier.FailFunc}
+	a.AsError(&target)
+	verifier.Verify(t)
+	if target == nil || target.code != 404 {
+		t.Fatalf("Failed: target %v", target)
+	}
+
+	var pathErr *fs.PathError
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: wrapped, fail: verifier.FailFunc}
+	a.AsError(&pathErr)
+	verifier.VerifyMessage(t, "request failed: code 404 does not match *fs.PathError")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: wrapped, fail: verifier.FailFunc}
+	a.AsError(target)
+	verifier.Verify(t)
+}
@@ -0,0 +1,110 @@
+package goblin
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"sync"
+)
+
+// CapturedOutput is what a function wrote to os.Stdout and os.Stderr while
+// run by CaptureOutput.
+type CapturedOutput struct {
+	Stdout string
+	Stderr string
+}
+
+// CaptureOption changes how CaptureOutput captures output.
+type CaptureOption func(c *captureConfig)
+
+type captureConfig struct {
+	logger bool
+	attach bool
+}
+
+var (
+	// CaptureLogger also captures the standard logger, whose output is
+	// included in Stderr. Without it, the standard logger keeps writing to
+	// where it was set up to, even if that is the original os.Stderr.
+	CaptureLogger CaptureOption = func(c *captureConfig) { c.logger = true }
+	// AttachOutput also writes the captured output to the output of the
+	// running spec, which is displayed if it fails or when running in
+	// verbose mode.
+	AttachOutput CaptureOption = func(c *captureConfig) { c.attach = true }
+)
+
+// captureMu serializes captures, since os.Stdout and os.Stderr are shared by
+// every spec
+var captureMu sync.Mutex
+
+// CaptureOutput runs f with os.Stdout and os.Stderr redirected, and returns
+// what it wrote to them, e.g. to assert on the output of a command:
+//
+//	out := g.CaptureOutput(func() { cmd.Execute() })
+//	g.Assert(out.Stdout).Equal("done\n")
+//
+// Captures by specs running in parallel wait for each other, and f must not
+// call CaptureOutput itself.
+func (g *G) CaptureOutput(f func(), options ...CaptureOption) CapturedOutput {
+	var config captureConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	out := capture(f, config.logger)
+	if config.attach {
+		io.WriteString(g.Writer(), out.Stdout)
+		io.WriteString(g.Writer(), out.Stderr)
+	}
+	return out
+}
+
+// capture runs f with os.Stdout and os.Stderr, and the standard logger if
+// logger is set, redirected to pipes, restoring them even if f exits its
+// goroutine, e.g. because of a failed assertion
+func capture(f func(), logger bool) (out CapturedOutput) {
+	captureMu.Lock()
+	defer captureMu.Unlock()
+
+	stdout, stdoutDone := pipe(&out.Stdout)
+	stderr, stderrDone := pipe(&out.Stderr)
+	originalStdout, originalStderr := os.Stdout, os.Stderr
+	writer, flags := log.Writer(), log.Flags()
+	defer func() {
+		os.Stdout, os.Stderr = originalStdout, originalStderr
+		if logger {
+			log.SetOutput(writer)
+			log.SetFlags(flags)
+		}
+		stdout.Close()
+		stderr.Close()
+		<-stdoutDone
+		<-stderrDone
+	}()
+
+	os.Stdout, os.Stderr = stdout, stderr
+	if logger {
+		log.SetOutput(stderr)
+	}
+	f()
+	return
+}
+
+// pipe creates a pipe whose content is read into to, returning its write end
+// and a channel closed once it's closed and read
+func pipe(to *string) (*os.File, <-chan struct{}) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer r.Close()
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		*to = buf.String()
+	}()
+	return w, done
+}
@@ -0,0 +1,51 @@
+package goblin
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCaptureOutput(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var out, logged CapturedOutput
+	g.Describe("CaptureOutput", func() {
+		g.It("Should capture stdout and stderr", func() {
+			out = g.CaptureOutput(func() {
+				fmt.Println("to stdout")
+				fmt.Fprintln(os.Stderr, "to stderr")
+			})
+		})
+
+		g.It("Should capture the standard logger", func() {
+			logged = g.CaptureOutput(func() {
+				log.SetFlags(0)
+				log.Print("logged")
+			}, CaptureLogger)
+		})
+
+		g.It("Should attach the output to the spec", func() {
+			g.CaptureOutput(func() {
+				fmt.Println("attached")
+			}, AttachOutput)
+			g.Fail("failed")
+		})
+	})
+
+	if out.Stdout != "to stdout\n" || out.Stderr != "to stderr\n" {
+		t.Fatalf("Failed: output %+v", out)
+	}
+	if logged.Stderr != "logged\n" || log.Flags() != log.LstdFlags {
+		t.Fatalf("Failed: logged %+v, flags %d", logged, log.Flags())
+	}
+	if len(reporter.captured) != 1 || !strings.Contains(reporter.captured[0].Output, "attached\n") {
+		t.Fatalf("Failed: failures %+v", reporter.captured)
+	}
+}
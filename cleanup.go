@@ -0,0 +1,88 @@
+package goblin
+
+import (
+	"sync"
+)
+
+// cleanups holds the functions registered with Cleanup for a spec or a block
+type cleanups struct {
+	mu    sync.Mutex
+	funcs []func()
+}
+
+func (c *cleanups) add(f func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.funcs = append(c.funcs, f)
+}
+
+// take returns the registered functions in the order to run them, the last
+// registered first, and forgets them
+func (c *cleanups) take() []func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	funcs := make([]func(), 0, len(c.funcs))
+	for i := len(c.funcs) - 1; i >= 0; i-- {
+		funcs = append(funcs, c.funcs[i])
+	}
+	c.funcs = nil
+	return funcs
+}
+
+// Cleanup registers f to run once the spec or block being run finishes, even
+// if it fails or times out, so resources can be released where they are
+// acquired instead of in a matching After hook.
+//
+// Called from an It or from a BeforeEach, JustBeforeEach, JustAfterEach or
+// AfterEach hook, f runs once the spec and its hooks are done, and failing
+// assertions within it fail the spec. Called from a Before or After hook, f
+// runs after the After hooks of the block. Functions run in the reverse order
+// they were registered, like deferred calls.
+func (g *G) Cleanup(f func()) {
+	current, _ := g.active().current()
+	switch current := current.(type) {
+	case *It:
+		current.cleanups.add(f)
+	case *hookFailure:
+		if current.scope == nil {
+			panic("Cleanup can't be called from a suite hook, use AfterSuite instead.")
+		}
+		current.scope.cleanups.add(f)
+	default:
+		panic("Cleanup should be called inside an It() block or a hook.")
+	}
+}
+
+// runCleanups runs the cleanups registered while the spec ran
+func (it *It) runCleanups(g *G) {
+	for _, f := range it.cleanups.take() {
+		f := f
+		stop := g.startSignal(false)
+		go func() {
+			// Failing stops the cleanup's goroutine, along with the wait for it
+			defer stop.stop()
+			g.setHook(`"cleanup" hook`)
+			defer g.setHook("")
+			f()
+		}()
+		<-stop.c
+	}
+}
+
+// runCleanups runs the cleanups registered by the Before and After hooks of
+// the block, returning whether any of them failed
+func (d *Describe) runCleanups(g *G) bool {
+	failed := false
+	for _, f := range d.cleanups.take() {
+		f := f
+		err := d.runBlockHook(g, `"cleanup" hook`, func() error {
+			f()
+			return nil
+		})
+		if err != nil {
+			failed = true
+			d.hookFailed(g, `"cleanup" hook`, err)
+		}
+	}
+	return failed
+}
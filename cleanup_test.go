@@ -0,0 +1,89 @@
+package goblin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCleanup(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var ran []string
+	cleanup := func(name string) func() {
+		return func() {
+			ran = append(ran, name)
+		}
+	}
+	g.Describe("Resources", func() {
+		g.Before(func() {
+			g.Cleanup(cleanup("Before cleanup"))
+		})
+		g.After(func() {
+			ran = append(ran, "After")
+		})
+		g.BeforeEach(func() {
+			g.Cleanup(cleanup("BeforeEach cleanup"))
+		})
+		g.AfterEach(func() {
+			ran = append(ran, "AfterEach")
+		})
+
+		g.It("Should clean up in reverse order", func() {
+			g.Cleanup(cleanup("It cleanup"))
+			ran = append(ran, "It")
+		})
+
+		g.It("Should clean up after failing", func() {
+			g.Cleanup(cleanup("failed cleanup"))
+			g.Fail("failed")
+		})
+	})
+
+	g.Describe("Slow resources", func() {
+		g.It("Should clean up after timing out", func() {
+			g.Cleanup(cleanup("timed out cleanup"))
+			time.Sleep(50 * time.Millisecond)
+		}, Timeout(10*time.Millisecond))
+	})
+
+	expected := []string{
+		"It", "AfterEach", "It cleanup", "BeforeEach cleanup",
+		"AfterEach", "failed cleanup", "BeforeEach cleanup",
+		"After", "Before cleanup",
+		"timed out cleanup",
+	}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should clean up after failing", "Should clean up after timing out"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestFailingCleanup(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Resources", func() {
+		g.It("Should fail when cleaning up fails", func() {
+			g.Cleanup(func() {
+				g.Fail("could not close")
+			})
+		})
+	})
+
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail when cleaning up fails"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if msg := reporter.captured[0].Message; msg != `"cleanup" hook failed: could not close` {
+		t.Fatalf("Failed: message %q", msg)
+	}
+}
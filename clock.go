@@ -0,0 +1,191 @@
+package goblin
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of the runner, used for timeouts and the
+// reported durations of specs.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer created by a Clock.
+type Timer interface {
+	// C returns the channel on which the time is delivered once the timer
+	// fires.
+	C() <-chan time.Time
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// SetClock replaces the runner's clock, e.g. with a fake one in tests of
+// reporters.
+func (g *G) SetClock(c Clock) {
+	g.clock = c
+}
+
+// runnerTimer creates a timer of the runner itself, such as the timeout of a
+// spec, which FakeClock.BlockUntil doesn't wait for
+func (g *G) runnerTimer(d time.Duration) Timer {
+	if c, ok := g.clock.(*FakeClock); ok {
+		return c.newTimer(d, true)
+	}
+	return g.clock.NewTimer(d)
+}
+
+// realClock is the Clock of the system
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// FakeClock is a Clock whose time only moves when advanced, for specs of
+// time-dependent code which shouldn't sleep, or of timeouts when set as the
+// runner's clock with SetClock. Timers fire once the clock is advanced past
+// their deadline.
+type FakeClock struct {
+	mu      sync.Mutex
+	changed *sync.Cond // Broadcast when timers are added
+	now     time.Time
+	timers  []*fakeTimer
+}
+
+// NewFakeClock creates a FakeClock set to start.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	return c.newTimer(d, false)
+}
+
+// newTimer creates a timer, which BlockUntil doesn't wait for if it's one of
+// the runner's own
+func (c *FakeClock) newTimer(d time.Duration, runner bool) Timer {
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1), runner: runner}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward by d, firing the timers due by then in the
+// order of their deadlines.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	end := c.now.Add(d)
+	for {
+		next := -1
+		for i, t := range c.timers {
+			if !t.deadline.After(end) && (next < 0 || t.deadline.Before(c.timers[next].deadline)) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		t := c.timers[next]
+		c.timers = append(c.timers[:next], c.timers[next+1:]...)
+		if t.deadline.After(c.now) {
+			c.now = t.deadline
+		}
+		// Like time.Timer, drop the time if the last one wasn't received
+		select {
+		case t.c <- c.now:
+		default:
+		}
+	}
+	c.now = end
+}
+
+// BlockUntil waits until at least n timers are pending, e.g. until the code
+// under test, running in another goroutine, started waiting on the clock. The
+// timers of the runner itself, such as the timeouts of specs, aren't counted.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.pending() < n {
+		c.changed.Wait()
+	}
+}
+
+// pending returns how many timers other than the runner's are pending
+func (c *FakeClock) pending() int {
+	n := 0
+	for _, t := range c.timers {
+		if !t.runner {
+			n++
+		}
+	}
+	return n
+}
+
+// remove stops waiting for t, returning whether it was pending
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	deadline time.Time
+	runner   bool // Whether the timer is one of the runner's own
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.remove(t)
+	t.deadline = c.now.Add(d)
+	if d <= 0 {
+		select {
+		case t.c <- c.now:
+		default:
+		}
+		return active
+	}
+	c.timers = append(c.timers, t)
+	c.changed.Broadcast()
+	return active
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove(t)
+}
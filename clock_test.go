@@ -0,0 +1,189 @@
+package goblin
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// steppingClock advances by a second every time it's read. Its timers either
+// fire as soon as they're created, or never.
+type steppingClock struct {
+	now  time.Time
+	fire bool
+	mu   sync.Mutex
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func (c *steppingClock) NewTimer(d time.Duration) Timer {
+	return steppingTimer{fire: c.fire}
+}
+
+type steppingTimer struct {
+	fire bool
+}
+
+func (t steppingTimer) C() <-chan time.Time {
+	if !t.fire {
+		return nil
+	}
+	c := make(chan time.Time, 1)
+	c <- time.Time{}
+	return c
+}
+
+func (steppingTimer) Reset(d time.Duration) bool { return false }
+func (steppingTimer) Stop() bool                 { return false }
+
+func TestClock(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	clock := &steppingClock{}
+	g.SetClock(clock)
+
+	g.Describe("Clock", func() {
+		g.It("Should take a second", func() {})
+	})
+
+	if reporter.executionTime != time.Second {
+		t.Fatalf("Failed: took %s", reporter.executionTime)
+	}
+
+	clock.fire = true
+	g.Describe("Timeout", func() {
+		g.It("Should time out without waiting", func(done Done) {})
+	})
+
+	if len(reporter.fails) != 1 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	second, minute := clock.NewTimer(time.Second), clock.NewTimer(time.Minute)
+	stopped := clock.NewTimer(time.Second)
+	if !stopped.Stop() || stopped.Stop() {
+		t.Fatalf("Failed: stopping a pending timer")
+	}
+
+	clock.Advance(30 * time.Second)
+	if fired := <-second.C(); !fired.Equal(start.Add(time.Second)) {
+		t.Fatalf("Failed: fired at %s", fired)
+	}
+	select {
+	case <-minute.C():
+		t.Fatalf("Failed: fired before its deadline")
+	case <-stopped.C():
+		t.Fatalf("Failed: stopped timer fired")
+	default:
+	}
+	if !clock.Now().Equal(start.Add(30 * time.Second)) {
+		t.Fatalf("Failed: now %s", clock.Now())
+	}
+
+	if minute.Reset(time.Minute) != true {
+		t.Fatalf("Failed: resetting a pending timer")
+	}
+	clock.Advance(time.Minute)
+	if fired := <-minute.C(); !fired.Equal(start.Add(90 * time.Second)) {
+		t.Fatalf("Failed: fired at %s", fired)
+	}
+}
+
+func TestFakeClockTimeout(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	clock := NewFakeClock(time.Now())
+	g.SetClock(clock)
+
+	started := make(chan struct{})
+	go func() {
+		<-started
+		clock.Advance(time.Hour)
+	}()
+	g.Describe("Fake clock", func() {
+		g.It("Should time out once the clock is advanced", func(done Done) {
+			close(started)
+		}, Timeout(time.Hour))
+	})
+
+	if len(reporter.fails) != 1 || !clock.Now().After(time.Now().Add(59*time.Minute)) {
+		t.Fatalf("Failed: fails %v, now %s", reporter.fails, clock.Now())
+	}
+}
+
+func TestWithoutHookTime(t *testing.T) {
+	for _, test := range []struct {
+		options  []Option
+		duration time.Duration
+	}{
+		{nil, 3 * time.Second},
+		{[]Option{WithoutHookTime()}, 2 * time.Second},
+	} {
+		fakeTest := testing.T{}
+		reporter := specReporter{}
+
+		g := Goblin(&fakeTest, test.options...)
+		g.SetReporter(Reporter(&reporter))
+		clock := NewFakeClock(time.Now())
+		g.SetClock(clock)
+
+		g.Describe("Clock", func() {
+			g.BeforeEach(func() {
+				clock.Advance(time.Second)
+			})
+			g.It("Should take two seconds", func() {
+				clock.Advance(2 * time.Second)
+			}, Timeout(time.Hour))
+		})
+
+		if len(reporter.reports) != 1 || reporter.reports[0].Duration != test.duration {
+			t.Fatalf("Failed: reports %v", reporter.reports)
+		}
+	}
+}
+
+func TestFakeClockBlockUntil(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	clock := NewFakeClock(time.Now())
+	g.SetClock(clock)
+
+	g.Describe("Fake clock", func() {
+		g.It("Should not wait for the clock", func() {}, Timeout(time.Hour))
+		g.It("Should fire the timer of the spec", func() {
+			fired := make(chan time.Time)
+			go func() {
+				fired <- <-clock.NewTimer(time.Minute).C()
+			}()
+			// Waits for the timer above, not the timeouts of the specs
+			clock.BlockUntil(1)
+			clock.Advance(time.Minute)
+			<-fired
+		}, Timeout(time.Hour))
+	})
+
+	if len(reporter.passes) != 2 {
+		t.Fatalf("Failed: passes %v, fails %v", reporter.passes, reporter.fails)
+	}
+	if len(clock.timers) != 0 {
+		t.Fatalf("Failed: %d timers left pending", len(clock.timers))
+	}
+}
@@ -0,0 +1,169 @@
+package goblin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dimension is a named parameter of a generated table together with the
+// values it can take.
+type Dimension struct {
+	Name   string
+	Values []interface{}
+}
+
+// Dim creates a Dimension.
+func Dim(name string, values ...interface{}) Dimension {
+	return Dimension{Name: name, Values: values}
+}
+
+// Combinator generates table entries from combinations of the values of
+// several dimensions. Each generated entry has one argument per dimension, in
+// the order the dimensions were given.
+type Combinator struct {
+	dims []Dimension
+}
+
+// Combinations creates a Combinator over dims, e.g.
+//
+//	g.DescribeTable("Config", body,
+//		goblin.Combinations(
+//			goblin.Dim("os", "linux", "darwin"),
+//			goblin.Dim("arch", "amd64", "arm64"),
+//			goblin.Dim("cgo", true, false),
+//		).Pairwise()...)
+func Combinations(dims ...Dimension) *Combinator {
+	return &Combinator{dims: dims}
+}
+
+// All returns an entry for every combination of values, i.e. the full cross
+// product of the dimensions. There are none if any dimension has no values.
+func (c *Combinator) All() []TableEntry {
+	if c.empty() {
+		return nil
+	}
+
+	var entries []TableEntry
+	row := make([]int, len(c.dims))
+	for {
+		entries = append(entries, c.entry(row))
+
+		// Advance the row like an odometer, last dimension first
+		i := len(row) - 1
+		for ; i >= 0; i-- {
+			row[i]++
+			if row[i] < len(c.dims[i].Values) {
+				break
+			}
+			row[i] = 0
+		}
+		if i < 0 {
+			return entries
+		}
+	}
+}
+
+// empty returns whether there are no combinations, as there are no dimensions
+// or one of them has no values
+func (c *Combinator) empty() bool {
+	if len(c.dims) == 0 {
+		return true
+	}
+	for _, dim := range c.dims {
+		if len(dim.Values) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// pair identifies a value of dimension i combined with a value of dimension j
+type pair struct {
+	i, vi, j, vj int
+}
+
+// Pairwise returns entries covering every pair of values of any two
+// dimensions at least once, which takes far fewer entries than All for more
+// than two dimensions. Generation is deterministic.
+func (c *Combinator) Pairwise() []TableEntry {
+	if len(c.dims) < 3 || c.empty() {
+		return c.All()
+	}
+
+	uncovered := map[pair]bool{}
+	var order []pair
+	for i := range c.dims {
+		for j := i + 1; j < len(c.dims); j++ {
+			for vi := range c.dims[i].Values {
+				for vj := range c.dims[j].Values {
+					p := pair{i, vi, j, vj}
+					uncovered[p] = true
+					order = append(order, p)
+				}
+			}
+		}
+	}
+
+	var entries []TableEntry
+	for _, seed := range order {
+		if !uncovered[seed] {
+			continue
+		}
+
+		// Start from an uncovered pair, then greedily pick the value of each
+		// remaining dimension covering the most new pairs
+		row := make([]int, len(c.dims))
+		set := make([]bool, len(c.dims))
+		row[seed.i], row[seed.j] = seed.vi, seed.vj
+		set[seed.i], set[seed.j] = true, true
+		for d := range c.dims {
+			if set[d] {
+				continue
+			}
+			best, bestCount := 0, -1
+			for v := range c.dims[d].Values {
+				count := 0
+				for o := range c.dims {
+					if !set[o] {
+						continue
+					}
+					if uncovered[orderedPair(o, row[o], d, v)] {
+						count++
+					}
+				}
+				if count > bestCount {
+					best, bestCount = v, count
+				}
+			}
+			row[d], set[d] = best, true
+		}
+
+		for i := range c.dims {
+			for j := i + 1; j < len(c.dims); j++ {
+				delete(uncovered, pair{i, row[i], j, row[j]})
+			}
+		}
+		entries = append(entries, c.entry(row))
+	}
+	return entries
+}
+
+// orderedPair creates the pair for the given dimensions and values, with the
+// lower dimension first
+func orderedPair(a, va, b, vb int) pair {
+	if a > b {
+		return pair{b, vb, a, va}
+	}
+	return pair{a, va, b, vb}
+}
+
+// entry creates the table entry for the given value indexes
+func (c *Combinator) entry(row []int) TableEntry {
+	args := make([]interface{}, len(row))
+	names := make([]string, len(row))
+	for i, v := range row {
+		args[i] = c.dims[i].Values[v]
+		names[i] = fmt.Sprintf("%s=%v", c.dims[i].Name, args[i])
+	}
+	return TableEntry{Description: strings.Join(names, ", "), Args: args}
+}
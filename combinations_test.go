@@ -0,0 +1,87 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCombinationsAll(t *testing.T) {
+	entries := Combinations(Dim("a", 1, 2), Dim("b", "x", "y")).All()
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Description)
+	}
+	expected := []string{"a=1, b=x", "a=1, b=y", "a=2, b=x", "a=2, b=y"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Failed: names %v", names)
+	}
+	if !reflect.DeepEqual(entries[1].Args, []interface{}{1, "y"}) {
+		t.Fatalf("Failed: args %v", entries[1].Args)
+	}
+}
+
+func TestCombinationsPairwise(t *testing.T) {
+	dims := []Dimension{
+		Dim("os", "linux", "darwin", "windows"),
+		Dim("arch", "amd64", "arm64", "386"),
+		Dim("cgo", true, false),
+		Dim("race", true, false),
+	}
+	entries := Combinations(dims...).Pairwise()
+
+	if len(entries) >= len(Combinations(dims...).All()) {
+		t.Fatalf("Failed: pairwise generated %d entries", len(entries))
+	}
+
+	// Every pair of values of every two dimensions must be covered
+	covered := map[string]bool{}
+	for _, e := range entries {
+		for i := range e.Args {
+			for j := i + 1; j < len(e.Args); j++ {
+				covered[fmt.Sprint(i, e.Args[i], j, e.Args[j])] = true
+			}
+		}
+	}
+	for i := range dims {
+		for j := i + 1; j < len(dims); j++ {
+			for _, vi := range dims[i].Values {
+				for _, vj := range dims[j].Values {
+					if !covered[fmt.Sprint(i, vi, j, vj)] {
+						t.Fatalf("Failed: %s=%v, %s=%v not covered", dims[i].Name, vi, dims[j].Name, vj)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCombinationsEmptyDimension(t *testing.T) {
+	if entries := Combinations(Dim("a", 1, 2), Dim("b")).All(); entries != nil {
+		t.Fatalf("Failed: all %v", entries)
+	}
+	if entries := Combinations(Dim("a", 1, 2), Dim("b")).Pairwise(); entries != nil {
+		t.Fatalf("Failed: pairwise %v", entries)
+	}
+	if entries := Combinations(Dim("a", 1), Dim("b", 2), Dim("c")).Pairwise(); entries != nil {
+		t.Fatalf("Failed: pairwise %v", entries)
+	}
+}
+
+func TestCombinationsTable(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	count := 0
+	g.DescribeTable("Matrix", func(a int, b string) {
+		count++
+	}, Combinations(Dim("a", 1, 2), Dim("b", "x")).All()...)
+
+	if count != 2 || !reflect.DeepEqual(reporter.passes, []string{"a=1, b=x", "a=2, b=x"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+}
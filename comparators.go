@@ -0,0 +1,107 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// comparators holds the comparators and formatters registered for types
+type comparators struct {
+	mu     sync.RWMutex
+	equal  map[reflect.Type]func(a, b interface{}) bool
+	format map[reflect.Type]func(v interface{}) string
+}
+
+// globalComparators apply to every suite
+var globalComparators = &comparators{}
+
+// registry returns the comparators of g, or the global ones if g is nil
+func registry(g *G) *comparators {
+	if g == nil {
+		return globalComparators
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.comparators == nil {
+		g.comparators = &comparators{}
+	}
+	return g.comparators
+}
+
+// RegisterComparator registers how Equal compares values of type T, e.g. to
+// compare decimals by value rather than by representation. If g is nil the
+// comparator applies to every suite, otherwise only to g, where it takes
+// precedence over a global one.
+func RegisterComparator[T any](g *G, equal func(a, b T) bool) {
+	c := registry(g)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.equal == nil {
+		c.equal = map[reflect.Type]func(a, b interface{}) bool{}
+	}
+	c.equal[reflect.TypeOf((*T)(nil)).Elem()] = func(a, b interface{}) bool {
+		return equal(a.(T), b.(T))
+	}
+}
+
+// RegisterFormatter registers how values of type T are displayed in the
+// messages of failed assertions, e.g. to display times in RFC 3339. If g is
+// nil the formatter applies to every suite, otherwise only to g, where it
+// takes precedence over a global one.
+func RegisterFormatter[T any](g *G, format func(v T) string) {
+	c := registry(g)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.format == nil {
+		c.format = map[reflect.Type]func(v interface{}) string{}
+	}
+	c.format[reflect.TypeOf((*T)(nil)).Elem()] = func(v interface{}) string {
+		return format(v.(T))
+	}
+}
+
+func (c *comparators) lookupEqual(t reflect.Type) func(a, b interface{}) bool {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.equal[t]
+}
+
+func (c *comparators) lookupFormat(t reflect.Type) func(v interface{}) string {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.format[t]
+}
+
+// equal compares a and b with the comparator registered for their type, if
+// any, falling back to objectsAreEqual
+func (a *Assertion) equal(b interface{}) bool {
+	t := reflect.TypeOf(a.src)
+	if t != nil && t == reflect.TypeOf(b) {
+		for _, c := range []*comparators{a.comparators, globalComparators} {
+			if equal := c.lookupEqual(t); equal != nil {
+				return equal(a.src, b)
+			}
+		}
+	}
+	return objectsAreEqual(a.src, b)
+}
+
+// format displays v with the formatter registered for its type, if any,
+// falling back to its Go syntax representation
+func (a *Assertion) format(v interface{}) string {
+	if t := reflect.TypeOf(v); t != nil {
+		for _, c := range []*comparators{a.comparators, globalComparators} {
+			if format := c.lookupFormat(t); format != nil {
+				return format(v)
+			}
+		}
+	}
+	return fmt.Sprintf("%#v", v)
+}
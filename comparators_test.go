@@ -0,0 +1,67 @@
+package goblin
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type money struct {
+	cents int
+	label string
+}
+
+func TestRegisterComparator(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	RegisterComparator(g, func(a, b money) bool { return a.cents == b.cents })
+	RegisterFormatter(g, func(t time.Time) string { return t.Format(time.RFC3339) })
+
+	g.Describe("Comparators", func() {
+		g.It("Should compare by value", func() {
+			g.Assert(money{100, "one dollar"}).Equal(money{100, "$1"})
+		})
+		g.It("Should format times", func() {
+			g.Assert(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)).Equal(time.Time{})
+		})
+	})
+
+	if len(reporter.passes) != 1 || len(reporter.captured) != 1 {
+		t.Fatalf("Failed: passes %v, fails %v", reporter.passes, reporter.fails)
+	}
+	expected := "2020-01-02T03:04:05Z does not equal 0001-01-01T00:00:00Z"
+	if reporter.captured[0].Message != expected {
+		t.Fatalf("Failed: message %q", reporter.captured[0].Message)
+	}
+
+	// Comparators of a suite don't leak into others
+	other := Goblin(&fakeTest)
+	verifier := AssertionVerifier{ShouldPass: false}
+	a := other.Assert(money{100, "one dollar"})
+	a.fail = verifier.FailFunc
+	a.Equal(money{100, "$1"})
+	verifier.Verify(t)
+}
+
+type celsius float64
+
+func TestRegisterGlobalComparator(t *testing.T) {
+	RegisterComparator(nil, func(a, b celsius) bool { return a-b < 0.5 && b-a < 0.5 })
+	RegisterFormatter(nil, func(c celsius) string { return fmt.Sprintf("%g°C", float64(c)) })
+	defer func() {
+		globalComparators = &comparators{}
+	}()
+
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: celsius(20.1), fail: verifier.FailFunc}
+	a.Equal(celsius(20.3))
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: celsius(20), fail: verifier.FailFunc}
+	a.Equal(celsius(21))
+	verifier.VerifyMessage(t, "20°C does not equal 21°C")
+}
@@ -0,0 +1,13 @@
+//go:build !windows
+
+package goblin
+
+import (
+	"os"
+)
+
+// enableColors returns whether the escape sequences coloring the output can be
+// written to f, which terminals other than the Windows console interpret as is.
+func enableColors(f *os.File) bool {
+	return true
+}
@@ -0,0 +1,216 @@
+package goblin
+
+import (
+	"time"
+)
+
+// Decorator changes how a spec is run. Decorators are passed alongside the
+// handler when declaring an It, alongside the arguments of a table entry, or
+// to a Describe, in which case they are inherited by everything nested in it.
+type Decorator interface {
+	decorate(c *specConfig)
+}
+
+// specConfig holds the settings decorators apply to a Describe or It
+type specConfig struct {
+	labels   []string
+	timeout  time.Duration
+	retries  int
+	backoff  time.Duration // Delay before the first retry, doubled for each one after
+	serial   bool
+	ordered  bool
+	source   string        // Where the data of a table entry was loaded from
+	xfail    string        // Why the spec is expected to fail, if it is
+	profiles []string      // Kinds of profiles to capture
+	failFast bool          // Whether the first failing spec skips the rest of the block
+	owner    string        // Who to route failures of the spec to
+	links    []string      // Issues, docs or dashboards related to the spec
+	budget   time.Duration // How long the spec may take, if limited
+	soft     bool          // Whether failed assertions let the spec go on
+}
+
+// inherit returns a copy of the config for a nested Describe or It
+func (c specConfig) inherit() specConfig {
+	c.labels = append([]string(nil), c.labels...)
+	c.profiles = append([]string(nil), c.profiles...)
+	c.links = append([]string(nil), c.links...)
+	return c
+}
+
+// Labels is a Decorator attaching labels to a spec.
+type Labels []string
+
+// Label creates a Decorator attaching the given labels to a spec.
+func Label(labels ...string) Labels {
+	return Labels(labels)
+}
+
+func (l Labels) decorate(c *specConfig) {
+	c.labels = append(c.labels, l...)
+}
+
+type timeoutDecorator time.Duration
+
+// Timeout creates a Decorator overriding the default timeout for a spec.
+func Timeout(d time.Duration) Decorator {
+	return timeoutDecorator(d)
+}
+
+func (d timeoutDecorator) decorate(c *specConfig) {
+	c.timeout = time.Duration(d)
+}
+
+// splitDecorators separates decorators from the other values in args.
+func splitDecorators(args []interface{}) ([]interface{}, []Decorator) {
+	var rest []interface{}
+	var decorators []Decorator
+	for _, arg := range args {
+		if d, ok := arg.(Decorator); ok {
+			decorators = append(decorators, d)
+		} else {
+			rest = append(rest, arg)
+		}
+	}
+	return rest, decorators
+}
+
+type retryDecorator int
+
+// Retry creates a Decorator which reruns a failing spec up to n more times.
+// The spec is only reported as failed if every attempt fails.
+func Retry(n int) Decorator {
+	return retryDecorator(n)
+}
+
+func (d retryDecorator) decorate(c *specConfig) {
+	c.retries = int(d)
+}
+
+// FlakeAttempts creates a Decorator which runs a failing spec up to attempts
+// times in total, i.e. Retry(attempts - 1).
+func FlakeAttempts(attempts int) Decorator {
+	return retryDecorator(attempts - 1)
+}
+
+type retryWithBackoffDecorator struct {
+	attempts     int
+	initialDelay time.Duration
+}
+
+// RetryWithBackoff creates a Decorator which runs a failing spec, including
+// its BeforeEach and AfterEach hooks, up to attempts times in total. The
+// first retry waits for initialDelay, and each one after waits twice as long
+// as the previous one. Meant for specs depending on eventually consistent
+// systems.
+func RetryWithBackoff(attempts int, initialDelay time.Duration) Decorator {
+	return retryWithBackoffDecorator{attempts: attempts, initialDelay: initialDelay}
+}
+
+func (d retryWithBackoffDecorator) decorate(c *specConfig) {
+	c.retries = d.attempts - 1
+	c.backoff = d.initialDelay
+}
+
+type serialDecorator struct{}
+
+// Serial is a Decorator marking specs which must never run concurrently with
+// other specs.
+var Serial Decorator = serialDecorator{}
+
+func (serialDecorator) decorate(c *specConfig) {
+	c.serial = true
+}
+
+type softDecorator struct{}
+
+// Soft is a Decorator making the failed assertions of specs record their
+// failure and let the spec go on, so all of them are reported together once
+// it ends. Async specs go on until done is called, instead of ending at the
+// first failure. Assertions in hooks, and calls to Fail, still stop the spec.
+var Soft Decorator = softDecorator{}
+
+func (softDecorator) decorate(c *specConfig) {
+	c.soft = true
+}
+
+type orderedDecorator struct{}
+
+// Ordered is a Describe Decorator marking a block whose specs depend on each
+// other and must run one after another in the order they are declared.
+var Ordered Decorator = orderedDecorator{}
+
+func (orderedDecorator) decorate(c *specConfig) {
+	c.ordered = true
+}
+
+type expectedToFailDecorator string
+
+// ExpectedToFail creates a Decorator for a spec which is known to fail, e.g.
+// because of an open bug, with reason linking to the issue. The spec still
+// runs, but its failure doesn't fail the suite. Once it passes, it's reported
+// as failed so the marker can be removed.
+func ExpectedToFail(reason string) Decorator {
+	return expectedToFailDecorator(reason)
+}
+
+func (d expectedToFailDecorator) decorate(c *specConfig) {
+	c.xfail = string(d)
+}
+
+type continueOnFailureDecorator bool
+
+// ContinueOnFailure creates a Describe Decorator setting whether the specs of
+// a block keep running after one of them fails. With ContinueOnFailure(false),
+// typically for an Ordered block of dependent steps, the first failing spec
+// skips the rest of the block, while other blocks run as usual.
+func ContinueOnFailure(continueOnFailure bool) Decorator {
+	return continueOnFailureDecorator(continueOnFailure)
+}
+
+func (d continueOnFailureDecorator) decorate(c *specConfig) {
+	c.failFast = !bool(d)
+}
+
+type budgetDecorator time.Duration
+
+// Budget creates a Decorator limiting how long a spec may take, including its
+// BeforeEach and AfterEach hooks. A spec taking longer fails with the measured
+// duration, or only records a warning with -goblin.budgets-as-warnings.
+func Budget(d time.Duration) Decorator {
+	return budgetDecorator(d)
+}
+
+func (d budgetDecorator) decorate(c *specConfig) {
+	c.budget = time.Duration(d)
+}
+
+type ownerDecorator string
+
+// Owner creates a Decorator recording who owns a spec, e.g. the team to route
+// its failures to. It's included in structured reports and the inventory.
+func Owner(owner string) Decorator {
+	return ownerDecorator(owner)
+}
+
+func (d ownerDecorator) decorate(c *specConfig) {
+	c.owner = string(d)
+}
+
+type linkDecorator string
+
+// Link creates a Decorator attaching a URL to a spec, such as the issue it
+// covers or its documentation. Links are included in structured reports and
+// the inventory, and accumulate when nested.
+func Link(url string) Decorator {
+	return linkDecorator(url)
+}
+
+func (d linkDecorator) decorate(c *specConfig) {
+	c.links = append(c.links, string(d))
+}
+
+type dataSource string
+
+func (d dataSource) decorate(c *specConfig) {
+	c.source = string(d)
+}
@@ -0,0 +1,428 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestItDecorators(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var it *It
+	g.Describe("Decorators", func() {
+		g.It("Should attach decorators in any position", Label("a"), func() {
+			it = g.currentIt.(*It)
+		}, Label("b"), Serial)
+
+		g.It("Should apply the timeout", func() {
+			time.Sleep(50 * time.Millisecond)
+		}, Timeout(10*time.Millisecond))
+	})
+
+	if !reflect.DeepEqual(it.labels, []string{"a", "b"}) || !it.serial {
+		t.Fatalf("Failed: labels %v serial %v", it.labels, it.serial)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should apply the timeout"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	flaky, broken := 0, 0
+	g.Describe("Retry", func() {
+		g.It("Should pass once an attempt passes", func() {
+			flaky++
+			g.Assert(flaky).Equal(3)
+		}, Retry(2))
+
+		g.It("Should fail if every attempt fails", func() {
+			broken++
+			g.Fail("broken")
+		}, Retry(1))
+	})
+
+	if flaky != 3 || broken != 2 {
+		t.Fatalf("Failed: flaky ran %d times, broken ran %d times", flaky, broken)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass once an attempt passes"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail if every attempt fails"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if reporter.failures != 1 {
+		t.Fatalf("Failed: reported %d failures", reporter.failures)
+	}
+}
+
+func TestFlakeAttempts(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := specReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	flaky, broken := 0, 0
+	g.Describe("Flaky", func() {
+		g.It("Should pass on the second attempt", func() {
+			flaky++
+			g.Assert(flaky).Equal(2)
+		}, FlakeAttempts(3))
+
+		g.ItRetry("Should fail after every attempt", 2, func() {
+			broken++
+			g.Fail("broken")
+		})
+	})
+
+	if flaky != 2 || broken != 2 {
+		t.Fatalf("Failed: flaky ran %d times, broken ran %d times", flaky, broken)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail after every attempt"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if attempts := []int{reporter.reports[0].Attempts, reporter.reports[1].Attempts}; !reflect.DeepEqual(attempts, []int{2, 2}) {
+		t.Fatalf("Failed: attempts %v", attempts)
+	}
+}
+
+func TestDescribeDecorators(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	configs := map[string]specConfig{}
+	record := func() {
+		it := g.currentIt.(*It)
+		configs[it.name] = it.specConfig
+	}
+
+	g.Describe("Outer", func() {
+		g.It("outer", record)
+
+		g.Describe("Inner", func() {
+			g.It("inherited", record)
+			g.It("overridden", record, Label("fast"), Timeout(time.Second))
+		}, Label("inner"), Serial)
+
+		g.It("sibling", record)
+	}, Label("outer"), Timeout(time.Minute), Ordered)
+
+	expected := map[string]specConfig{
+		"outer":      {labels: []string{"outer"}, timeout: time.Minute, ordered: true},
+		"inherited":  {labels: []string{"outer", "inner"}, timeout: time.Minute, ordered: true, serial: true},
+		"overridden": {labels: []string{"outer", "inner", "fast"}, timeout: time.Second, ordered: true, serial: true},
+		"sibling":    {labels: []string{"outer"}, timeout: time.Minute, ordered: true},
+	}
+	if !reflect.DeepEqual(configs, expected) {
+		t.Fatalf("Failed: configs %+v", configs)
+	}
+}
+
+type expectedFailureReporter struct {
+	FakeReporter
+	expected []string
+}
+
+func (r *expectedFailureReporter) ItFailedAsExpected(name, reason string) {
+	r.expected = append(r.expected, name+": "+reason)
+}
+
+func TestExpectedToFail(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := expectedFailureReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Known bugs", func() {
+		g.It("Should still fail", func() {
+			g.Assert(1).Equal(2)
+		}, ExpectedToFail("#12"))
+	})
+
+	if fakeTest.Failed() {
+		t.Fatal("Failed: expected failure failed the suite")
+	}
+	if !reflect.DeepEqual(reporter.expected, []string{"Should still fail: #12"}) || len(reporter.fails) != 0 {
+		t.Fatalf("Failed: expected %v, fails %v", reporter.expected, reporter.fails)
+	}
+
+	g.Describe("Fixed bugs", func() {
+		g.It("Should pass now", func() {}, ExpectedToFail("#13"))
+	})
+
+	if !fakeTest.Failed() || !reflect.DeepEqual(reporter.fails, []string{"Should pass now"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+type skipReporter struct {
+	FakeReporter
+	skipped []string
+}
+
+func (r *skipReporter) ItSkipped(name, reason string) {
+	r.skipped = append(r.skipped, name+": "+reason)
+}
+
+func TestContinueOnFailure(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := skipReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Checkout", func() {
+		g.Describe("Steps", func() {
+			g.It("Should add to cart", func() {})
+			g.It("Should pay", func() {
+				g.Fail("declined")
+			})
+			g.It("Should ship", func() {})
+			g.Describe("Delivery", func() {
+				g.It("Should deliver", func() {})
+			})
+		}, Ordered, ContinueOnFailure(false))
+		g.It("Should keep running other blocks", func() {})
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should add to cart", "Should keep running other blocks"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	expected := []string{
+		`Should ship: a previous spec of "Steps" failed`,
+		`Should deliver: a previous spec of "Steps" failed`,
+	}
+	if !reflect.DeepEqual(reporter.skipped, expected) {
+		t.Fatalf("Failed: skipped %v", reporter.skipped)
+	}
+}
+
+// backoffClock records the delays waited for, without waiting. Only the
+// timeout timers of specs never fire.
+type backoffClock struct {
+	realClock
+	delays []time.Duration
+}
+
+func (c *backoffClock) NewTimer(d time.Duration) Timer {
+	if d < time.Minute {
+		c.delays = append(c.delays, d)
+		return steppingTimer{fire: true}
+	}
+	return steppingTimer{}
+}
+
+type attemptReporter struct {
+	FakeReporter
+	attempts []string
+}
+
+func (r *attemptReporter) ItAttemptFailed(name string, attempt int, failure *Failure, delay time.Duration) {
+	r.attempts = append(r.attempts, fmt.Sprintf("%d: %s, %s", attempt, failure.Message, delay))
+}
+
+func TestRetryWithBackoff(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := attemptReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	clock := &backoffClock{}
+	g.SetClock(clock)
+
+	attempts, beforeEach := 0, 0
+	g.Describe("Backoff", func() {
+		g.BeforeEach(func() {
+			beforeEach++
+		})
+		g.It("Should pass eventually", func() {
+			attempts++
+			g.Assert(attempts).Equal(4)
+		}, RetryWithBackoff(4, 100*time.Millisecond), Timeout(time.Hour))
+	})
+
+	if attempts != 4 || beforeEach != 4 || len(reporter.passes) != 1 {
+		t.Fatalf("Failed: %d attempts, %d BeforeEach, passes %v", attempts, beforeEach, reporter.passes)
+	}
+	if !reflect.DeepEqual(clock.delays, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}) {
+		t.Fatalf("Failed: delays %v", clock.delays)
+	}
+	expected := []string{
+		"1: 1 does not equal 4, 100ms",
+		"2: 2 does not equal 4, 200ms",
+		"3: 3 does not equal 4, 400ms",
+	}
+	if !reflect.DeepEqual(reporter.attempts, expected) {
+		t.Fatalf("Failed: attempts %v", reporter.attempts)
+	}
+}
+
+func TestOwnerAndLink(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := specReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Payments", func() {
+		g.It("Should refund", func() {}, Link("https://example.com/issues/2"))
+		g.It("Should charge", func() {}, Owner("team-checkout"))
+	}, Owner("team-payments"), Link("https://example.com/docs"))
+
+	refund, charge := reporter.reports[0], reporter.reports[1]
+	if refund.Owner != "team-payments" || !reflect.DeepEqual(refund.Links, []string{"https://example.com/docs", "https://example.com/issues/2"}) {
+		t.Fatalf("Failed: report %+v", refund)
+	}
+	if charge.Owner != "team-checkout" || !reflect.DeepEqual(charge.Links, []string{"https://example.com/docs"}) {
+		t.Fatalf("Failed: report %+v", charge)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Budgets", func() {
+		g.It("Should fail over budget", func() {
+			time.Sleep(20 * time.Millisecond)
+		}, Budget(time.Millisecond), Timeout(time.Second))
+		g.It("Should pass within budget", func() {}, Budget(time.Second))
+	})
+
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail over budget"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if msg := reporter.captured[0].Message; !strings.Contains(msg, "over its budget of 1ms") {
+		t.Fatalf("Failed: message %q", msg)
+	}
+}
+
+func TestBudgetsAsWarnings(t *testing.T) {
+	*budgetsAsWarnings = true
+	defer func() {
+		*budgetsAsWarnings = false
+	}()
+
+	fakeTest := testing.T{}
+	reporter := warningReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Budgets", func() {
+		g.It("Should warn over budget", func() {
+			time.Sleep(20 * time.Millisecond)
+		}, Budget(time.Millisecond), Timeout(time.Second))
+	})
+
+	if fakeTest.Failed() || len(reporter.warned["Should warn over budget"]) != 1 {
+		t.Fatalf("Failed: warnings %v", reporter.warned)
+	}
+}
+
+func TestSoft(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var reached []string
+	g.Describe("Soft", func() {
+		g.It("Should report every failed assertion", func() {
+			g.Assert(1).Equal(2)
+			g.Assert("a").Equal("b")
+			reached = append(reached, "spec")
+		}, Soft)
+
+		g.Describe("Inherited", func() {
+			g.It("Should go on after a failed assertion", func() {
+				g.Assert(true).IsFalse()
+				reached = append(reached, "nested spec")
+				g.Fail("fatal")
+				reached = append(reached, "after Fail")
+			})
+		}, Soft)
+
+		g.It("Should stop at the first failed assertion otherwise", func() {
+			g.Assert(1).Equal(2)
+			reached = append(reached, "hard spec")
+		})
+	})
+
+	if !reflect.DeepEqual(reached, []string{"spec", "nested spec"}) {
+		t.Fatalf("Failed: reached %v", reached)
+	}
+	if len(reporter.captured) != 3 {
+		t.Fatalf("Failed: %d failures", len(reporter.captured))
+	}
+	failure := reporter.captured[0]
+	if failure.Message != "1 does not equal 2" || len(failure.Additional) != 1 ||
+		failure.Additional[0].Message != `"a" does not equal "b"` {
+		t.Fatalf("Failed: failure %+v", failure)
+	}
+	if !strings.Contains(failure.Additional[0].Stack[1], "decorators_test.go") {
+		t.Fatalf("Failed: stack %v", failure.Additional[0].Stack)
+	}
+	if failure := reporter.captured[1]; len(failure.Additional) != 1 || failure.Additional[0].Message != "fatal" {
+		t.Fatalf("Failed: failure %+v", failure)
+	}
+}
+
+func TestSoftAsync(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	reached := make(chan string, 2)
+	g.Describe("Soft", func() {
+		g.It("Should go on until done is called", func(done Done) {
+			go func() {
+				g.Assert(1).Equal(2)
+				reached <- "after the failure"
+				g.Assert("a").Equal("b")
+				reached <- "done"
+				done()
+			}()
+		}, Soft, Timeout(time.Second))
+	})
+
+	close(reached)
+	var got []string
+	for r := range reached {
+		got = append(got, r)
+	}
+	if !reflect.DeepEqual(got, []string{"after the failure", "done"}) {
+		t.Fatalf("Failed: reached %v", got)
+	}
+	if len(reporter.fails) != 1 || len(reporter.captured) != 1 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	failure := reporter.captured[0]
+	if failure.Message != "1 does not equal 2" || len(failure.Additional) != 1 ||
+		failure.Additional[0].Message != `"a" does not equal "b"` {
+		t.Fatalf("Failed: failure %+v", failure)
+	}
+}
@@ -1,7 +1,10 @@
 package goblin
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestBefore(t *testing.T) {
@@ -171,3 +174,165 @@ func TestNestedAfter(t *testing.T) {
 		t.Fatal("Failed")
 	}
 }
+
+func TestBeforeError(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := skipReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	ran := []string{}
+	g.Describe("Numbers", func() {
+		g.Before(func() error {
+			return errors.New("no database")
+		})
+
+		g.After(func() {
+			ran = append(ran, "After")
+		})
+
+		g.It("Should not run", func() {
+			ran = append(ran, "It")
+		})
+
+		g.Describe("Nested", func() {
+			g.Before(func() {
+				ran = append(ran, "Nested Before")
+			})
+
+			g.It("Should not run either", func() {
+				ran = append(ran, "Nested It")
+			})
+		})
+	})
+
+	if !fakeTest.Failed() {
+		t.Fatal("Failed: suite passed")
+	}
+	if !reflect.DeepEqual(ran, []string{"After"}) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{`"before all" hook`}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	expected := []string{
+		`Should not run: "before all" hook of "Numbers" failed`,
+		`Should not run either: "before all" hook of "Numbers" failed`,
+	}
+	if !reflect.DeepEqual(reporter.skipped, expected) {
+		t.Fatalf("Failed: skipped %v", reporter.skipped)
+	}
+}
+
+func TestAfterError(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Numbers", func() {
+		g.After(func() error {
+			return errors.New("could not clean up")
+		})
+
+		g.It("Should pass", func() {})
+	})
+
+	if !fakeTest.Failed() {
+		t.Fatal("Failed: suite passed")
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{`"after all" hook`}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestAssertInHooks(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	ran := []string{}
+	g.Describe("Numbers", func() {
+		g.Describe("Before", func() {
+			g.Before(func() {
+				g.Assert(1).Equal(2)
+				ran = append(ran, "Before")
+			})
+			g.It("Should not run", func() {
+				ran = append(ran, "It")
+			})
+		})
+
+		g.Describe("BeforeEach", func() {
+			g.BeforeEach(func() {
+				g.Fail("not ready")
+			})
+			g.It("Should not run either", func() {
+				ran = append(ran, "It")
+			}, Timeout(time.Second))
+		})
+
+		g.Describe("After", func() {
+			g.After(func() {
+				g.Fail("could not clean up")
+			})
+			g.It("Should pass", func() {})
+		})
+	})
+
+	if len(ran) != 0 {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	expected := []string{"\"before all\" hook", "Should not run either", "\"after all\" hook"}
+	if !reflect.DeepEqual(reporter.fails, expected) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	messages := []string{}
+	for _, failure := range reporter.captured {
+		messages = append(messages, failure.Message)
+	}
+	expected = []string{
+		"1 does not equal 2",
+		"\"before each\" hook failed: not ready",
+		"could not clean up",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Fatalf("Failed: messages %q", messages)
+	}
+}
+
+func TestFocus(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Numbers", func() {
+		g.It("Should be excluded", func() {})
+		g.Fit("Should run when focused", func() {})
+		g.FDescribe("Focused", func() {
+			g.It("Should run", func() {})
+			g.Describe("Nested", func() {
+				g.It("Should run too", func() {})
+			})
+		})
+		g.Describe("Unfocused", func() {
+			g.It("Should be excluded too", func() {})
+		})
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should run when focused", "Should run", "Should run too"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.excluded, []string{"Should be excluded", "Should be excluded too"}) {
+		t.Fatalf("Failed: excluded %v", reporter.excluded)
+	}
+}
@@ -0,0 +1,233 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxDiffDepth is how deep values are expanded when rendered for a diff
+	maxDiffDepth = 10
+	// maxDiffCells bounds the work of diffing, as lines of the expected value
+	// times lines of the actual one
+	maxDiffCells = 1 << 20
+	// diffContext is how many unchanged lines are shown around changes
+	diffContext = 3
+)
+
+// diffHeader starts the diff in failure messages, which reporters may use to
+// highlight it
+const diffHeader = "--- expected\n+++ actual"
+
+// diff returns a unified diff between the rendering of dst, the expected
+// value, and the source, if they are worth diffing: multi-line strings, and
+// structs, maps, slices and arrays of the same type without a registered
+// formatter.
+func (a *Assertion) diff(dst interface{}) (string, bool) {
+	t := reflect.TypeOf(a.src)
+	if t == nil || t != reflect.TypeOf(dst) {
+		return "", false
+	}
+	for _, c := range []*comparators{a.comparators, globalComparators} {
+		if c.lookupFormat(t) != nil {
+			return "", false
+		}
+	}
+
+	var expected, actual []string
+	kind := t.Kind()
+	if kind == reflect.Ptr {
+		kind = t.Elem().Kind()
+	}
+	switch kind {
+	case reflect.String:
+		if t.Kind() != reflect.String {
+			return "", false
+		}
+		src, dst := reflect.ValueOf(a.src).String(), reflect.ValueOf(dst).String()
+		if !strings.Contains(src, "\n") && !strings.Contains(dst, "\n") {
+			return "", false
+		}
+		expected, actual = strings.Split(dst, "\n"), strings.Split(src, "\n")
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		expected, actual = renderLines(dst), renderLines(a.src)
+	default:
+		return "", false
+	}
+
+	if len(expected)*len(actual) > maxDiffCells {
+		return "", false
+	}
+	return unifiedDiff(expected, actual)
+}
+
+// renderLines renders v in Go syntax, with one field or element per line
+func renderLines(v interface{}) []string {
+	var b strings.Builder
+	render(&b, reflect.ValueOf(v), 0)
+	return strings.Split(b.String(), "\n")
+}
+
+func render(b *strings.Builder, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		b.WriteString("nil")
+		return
+	}
+	if depth >= maxDiffDepth {
+		fmt.Fprintf(b, "%#v", v)
+		return
+	}
+
+	indent := strings.Repeat("    ", depth)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			fmt.Fprintf(b, "(%s)(nil)", v.Type())
+			return
+		}
+		b.WriteString("&")
+		render(b, v.Elem(), depth)
+	case reflect.Interface:
+		render(b, v.Elem(), depth)
+	case reflect.Struct:
+		fmt.Fprintf(b, "%s{\n", v.Type())
+		for i := 0; i < v.NumField(); i++ {
+			fmt.Fprintf(b, "%s    %s: ", indent, v.Type().Field(i).Name)
+			render(b, v.Field(i), depth+1)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "}")
+	case reflect.Map:
+		if v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+			return
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+		})
+		fmt.Fprintf(b, "%s{\n", v.Type())
+		for _, key := range keys {
+			fmt.Fprintf(b, "%s    %#v: ", indent, key)
+			render(b, v.MapIndex(key), depth+1)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "}")
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			fmt.Fprintf(b, "%s(nil)", v.Type())
+			return
+		}
+		fmt.Fprintf(b, "%s{\n", v.Type())
+		for i := 0; i < v.Len(); i++ {
+			b.WriteString(indent + "    ")
+			render(b, v.Index(i), depth+1)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "}")
+	case reflect.String:
+		fmt.Fprintf(b, "%q", v.String())
+	default:
+		// fmt formats the value held, even by unexported fields
+		fmt.Fprintf(b, "%#v", v)
+	}
+}
+
+// diffLine is a line of a diff, prefixed with '-' when only expected, '+' when
+// only actual, or ' ' when unchanged
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// unifiedDiff returns the diff from expected to actual, with runs of unchanged
+// lines away from the changes left out, or false if they don't differ
+func unifiedDiff(expected, actual []string) (string, bool) {
+	lines := diffLines(expected, actual)
+	shown := make([]bool, len(lines))
+	anyChanged := false
+	for i, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		anyChanged = true
+		for j := i - diffContext; j <= i+diffContext; j++ {
+			if j >= 0 && j < len(lines) {
+				shown[j] = true
+			}
+		}
+	}
+	if !anyChanged {
+		return "", false
+	}
+	// Eliding a single line wouldn't make the diff any shorter
+	for i := range lines {
+		if !shown[i] && (i == 0 || shown[i-1]) && (i == len(lines)-1 || shown[i+1]) {
+			shown[i] = true
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(diffHeader)
+	elided := false
+	for i, line := range lines {
+		if !shown[i] {
+			if !elided {
+				b.WriteString("\n ...")
+				elided = true
+			}
+			continue
+		}
+		elided = false
+		b.WriteString("\n" + string(line.op) + line.text)
+	}
+	return b.String(), true
+}
+
+// diffLines compares expected and actual line by line, keeping their longest
+// common subsequence unchanged
+func diffLines(expected, actual []string) []diffLine {
+	// lcs[i][j] is the length of the longest common subsequence of
+	// expected[i:] and actual[j:]
+	lcs := make([][]int, len(expected)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(expected) && j < len(actual) {
+		switch {
+		case expected[i] == actual[j]:
+			lines = append(lines, diffLine{' ', expected[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', expected[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', actual[j]})
+			j++
+		}
+	}
+	for ; i < len(expected); i++ {
+		lines = append(lines, diffLine{'-', expected[i]})
+	}
+	for ; j < len(actual); j++ {
+		lines = append(lines, diffLine{'+', actual[j]})
+	}
+	return lines
+}
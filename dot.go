@@ -0,0 +1,89 @@
+package goblin
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// dotsPerLine is how many specs a line of the DotReporter shows
+const dotsPerLine = 80
+
+// DotReporter writes a single character for each spec, "." when it passes,
+// "F" when it fails, "*" when pending and "x" when excluded or skipped,
+// followed by the same summary of the run and its failures as the
+// DetailedReporter, for suites too large to read the tree of. Selected with
+// -goblin.format=dot.
+type DotReporter struct {
+	*DetailedReporter
+	dots  int
+	quiet bool // Whether to leave out the characters, only writing the summary
+}
+
+// NewDotReporter creates a DotReporter writing to w, with colors from fancy.
+func NewDotReporter(w io.Writer, fancy TextFancier) *DotReporter {
+	return &DotReporter{DetailedReporter: NewDetailedReporter(w, fancy)}
+}
+
+// NewQuietReporter creates a DotReporter writing to w, with colors from
+// fancy, which leaves out the characters of the specs: only the summary of the
+// run and its failures are written, e.g. for CI logs. Selected with
+// -goblin.format=quiet.
+func NewQuietReporter(w io.Writer, fancy TextFancier) *DotReporter {
+	return &DotReporter{DetailedReporter: NewDetailedReporter(w, fancy), quiet: true}
+}
+
+// dot writes the character of a spec, starting a new line every dotsPerLine
+func (r *DotReporter) dot(char string) {
+	if r.quiet {
+		return
+	}
+	if r.dots%dotsPerLine == 0 {
+		fmt.Fprint(r.writer(), "\n  ")
+	}
+	fmt.Fprint(r.writer(), char)
+	r.dots++
+}
+
+func (r *DotReporter) BeginDescribe(name string) {}
+
+func (r *DotReporter) EndDescribe() {}
+
+func (r *DotReporter) ItFailed(name string) {
+	r.failed++
+	r.dot(r.fancy.Red("F"))
+}
+
+func (r *DotReporter) ItPassed(name string) {
+	r.passed++
+	r.dot(r.fancy.Gray("."))
+}
+
+func (r *DotReporter) ItIsPending(name string) {
+	r.pending++
+	r.dot(r.fancy.Cyan("*"))
+}
+
+func (r *DotReporter) ItIsExcluded(name string) {
+	r.excluded++
+	r.dot(r.fancy.Yellow("x"))
+}
+
+func (r *DotReporter) ItSkipped(name, reason string) {
+	r.ItIsExcluded(name)
+}
+
+func (r *DotReporter) ItFailedAsExpected(name, reason string) {
+	r.expectedFailures++
+	r.dot(r.fancy.Yellow("f"))
+}
+
+// The output of specs, their failed attempts and their measurements are left
+// out, the summary shows what failed
+
+func (r *DotReporter) ItOutput(name string, output string) {}
+
+func (r *DotReporter) ItAttemptFailed(name string, attempt int, failure *Failure, delay time.Duration) {
+}
+
+func (r *DotReporter) ItMeasured(name string, stats *Stats) {}
@@ -0,0 +1,77 @@
+package goblin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDotReporter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewDotReporter(&out, &Monochrome{})))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.Describe("Nested", func() {
+			g.It("Should subtract", func() {
+				g.Fail("failed")
+			}, Timeout(time.Second))
+		})
+		g.It("Should multiply")
+		g.Xit("Should divide", func() {})
+	})
+
+	lines := strings.Split(out.String(), "\n")
+	if lines[1] != "  .!F*x" {
+		t.Fatalf("Failed: dots\n%s", out.String())
+	}
+	for _, expected := range []string{"1 tests complete", "1 test(s) pending", "1 tests failed:", "1) Nested Should subtract", "    !failed\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Fatalf("Failed: %q in output\n%s", expected, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "Should add") {
+		t.Fatalf("Failed: passing specs in output\n%s", out.String())
+	}
+}
+
+func TestDotReporterWraps(t *testing.T) {
+	var out bytes.Buffer
+	r := NewDotReporter(&out, &Monochrome{})
+	for i := 0; i < dotsPerLine+1; i++ {
+		r.ItPassed("Should add")
+	}
+
+	expected := "\n  " + strings.Repeat(".", dotsPerLine) + "\n  ."
+	if out.String() != expected {
+		t.Fatalf("Failed: output\n%q", out.String())
+	}
+}
+
+func TestQuietReporter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewQuietReporter(&out, &Monochrome{})))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.It("Should subtract", func() {
+			g.Fail("failed")
+		}, Timeout(time.Second))
+	})
+
+	if !strings.HasPrefix(out.String(), "\n\n 1 tests complete") {
+		t.Fatalf("Failed: output before the summary\n%s", out.String())
+	}
+	for _, expected := range []string{"1 tests failed:", "1) Numbers Should subtract", "    !failed\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Fatalf("Failed: %q in output\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package goblin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// dryRunOutput is where -goblin.dry-run lists the specs
+var dryRunOutput io.Writer = os.Stdout
+
+// dryRun writes the block and its specs as an indented tree, marking the specs
+// which are pending or excluded
+func (d *Describe) dryRun(w io.Writer, level int) {
+	indent := strings.Repeat("  ", level)
+	fmt.Fprintln(w, indent+d.name)
+	for _, r := range d.children {
+		switch child := r.(type) {
+		case *Describe:
+			if child.hasTests {
+				child.dryRun(w, level+1)
+			}
+		case *It:
+			if child.h == nil {
+				fmt.Fprintf(w, "%s  - %s (pending)\n", indent, child.name)
+			} else {
+				fmt.Fprintf(w, "%s  %s\n", indent, child.name)
+			}
+		case *Xit:
+			fmt.Fprintf(w, "%s  - %s (excluded)\n", indent, child.name)
+		}
+	}
+}
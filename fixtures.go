@@ -0,0 +1,124 @@
+package goblin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// fixtureDecoders decode fixtures by the extension of their file
+var fixtureDecoders = struct {
+	mu     sync.RWMutex
+	decode map[string]func(data []byte, v interface{}) error
+}{decode: map[string]func(data []byte, v interface{}) error{".json": json.Unmarshal}}
+
+// RegisterFixtureDecoder registers how LoadFixture decodes files with the
+// extension ext, e.g. to support YAML fixtures, which goblin doesn't decode
+// itself to stay free of dependencies:
+//
+//	goblin.RegisterFixtureDecoder(".yaml", yaml.Unmarshal)
+func RegisterFixtureDecoder(ext string, decode func(data []byte, v interface{}) error) {
+	fixtureDecoders.mu.Lock()
+	defer fixtureDecoders.mu.Unlock()
+	fixtureDecoders.decode[strings.ToLower(ext)] = decode
+}
+
+// fixtureKey identifies a fixture loaded into a value of a type
+type fixtureKey struct {
+	path string
+	t    reflect.Type
+}
+
+// LoadFixture loads the file at path, relative to the package's testdata
+// directory unless absolute, into the value v points to, failing the spec if
+// it's missing or invalid. Files are loaded as is into a []byte or a string,
+// and otherwise decoded according to their extension: JSON is supported, and
+// other formats can be added with RegisterFixtureDecoder.
+//
+// Fixtures are decoded once per suite and type. Values loaded from the same
+// file share their maps, slices and pointers, so they shouldn't be modified.
+func (g *G) LoadFixture(path string, v interface{}) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join("testdata", path)
+	}
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		g.Fail(fmt.Sprintf("can't load fixture %s into %T, expected a non-nil pointer", path, v))
+		return
+	}
+
+	value, err := g.suite().fixture(path, target.Type().Elem())
+	if err != nil {
+		g.Fail(err)
+		return
+	}
+	target.Elem().Set(value)
+}
+
+// fixture returns the fixture at path decoded as a value of type t, decoding
+// it unless it already was
+func (g *G) fixture(path string, t reflect.Type) (reflect.Value, error) {
+	key := fixtureKey{path, t}
+	g.mutex.Lock()
+	value, ok := g.fixtures[key]
+	g.mutex.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	value, err := decodeFixture(path, t)
+	if err != nil {
+		return value, err
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.fixtures == nil {
+		g.fixtures = map[fixtureKey]reflect.Value{}
+	}
+	g.fixtures[key] = value
+	return value, nil
+}
+
+// decodeFixture reads the fixture at path as a value of type t
+func decodeFixture(path string, t reflect.Type) (reflect.Value, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return reflect.Value{}, fmt.Errorf("fixture %s doesn't exist", path)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("can't read fixture %s: %v", path, err)
+	}
+
+	value := reflect.New(t).Elem()
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		value.SetBytes(data)
+	case t.Kind() == reflect.String:
+		value.SetString(string(data))
+	default:
+		ext := strings.ToLower(filepath.Ext(path))
+		fixtureDecoders.mu.RLock()
+		decode := fixtureDecoders.decode[ext]
+		var supported []string
+		for ext := range fixtureDecoders.decode {
+			supported = append(supported, ext)
+		}
+		fixtureDecoders.mu.RUnlock()
+		if decode == nil {
+			sort.Strings(supported)
+			return reflect.Value{}, fmt.Errorf("fixture %s has an unsupported format, expected %s, or to load it into a []byte or string",
+				path, strings.Join(supported, ", "))
+		}
+		if err := decode(data, value.Addr().Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("fixture %s is invalid: %v", path, err)
+		}
+	}
+	return value, nil
+}
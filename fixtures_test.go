@@ -0,0 +1,102 @@
+package goblin
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fixtureUser struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+func TestLoadFixture(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var user, cached fixtureUser
+	var raw []byte
+	var text string
+	g.Describe("Fixtures", func() {
+		g.It("Should decode JSON", func() {
+			g.LoadFixture("user.json", &user)
+		})
+
+		g.It("Should load raw files", func() {
+			g.LoadFixture("user.toml", &raw)
+			g.LoadFixture("user.toml", &text)
+		})
+
+		g.It("Should decode once per suite", func() {
+			g.LoadFixture("user.json", &cached)
+		})
+
+		g.It("Should fail if missing", func() {
+			g.LoadFixture("missing.json", &user)
+		})
+
+		g.It("Should fail if invalid", func() {
+			g.LoadFixture("broken.json", &user)
+		})
+
+		g.It("Should fail if unsupported", func() {
+			g.LoadFixture("user.toml", &user)
+		})
+	})
+
+	if !reflect.DeepEqual(user, fixtureUser{Name: "ann", Tags: []string{"admin"}}) {
+		t.Fatalf("Failed: user %+v", user)
+	}
+	if !bytes.Equal(raw, []byte("name = \"ann\"\n")) || text != string(raw) {
+		t.Fatalf("Failed: raw %q, text %q", raw, text)
+	}
+	if &cached.Tags[0] != &user.Tags[0] {
+		t.Fatalf("Failed: decoded the fixture again")
+	}
+	expected := []string{
+		"fixture testdata/missing.json doesn't exist",
+		"fixture testdata/broken.json is invalid: unexpected end of JSON input",
+		"fixture testdata/user.toml has an unsupported format, expected .json, or to load it into a []byte or string",
+	}
+	if len(reporter.captured) != len(expected) {
+		t.Fatalf("Failed: %d failures", len(reporter.captured))
+	}
+	for i, failure := range reporter.captured {
+		if failure.Message != expected[i] {
+			t.Fatalf("Failed: message %q", failure.Message)
+		}
+	}
+}
+
+func TestRegisterFixtureDecoder(t *testing.T) {
+	defer func() {
+		fixtureDecoders.mu.Lock()
+		delete(fixtureDecoders.decode, ".toml")
+		fixtureDecoders.mu.Unlock()
+	}()
+	RegisterFixtureDecoder(".TOML", func(data []byte, v interface{}) error {
+		return errors.New("not implemented")
+	})
+
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Fixture decoders", func() {
+		g.It("Should decode with the registered decoder", func() {
+			var user fixtureUser
+			g.LoadFixture("user.toml", &user)
+		})
+	})
+
+	if len(reporter.captured) != 1 || reporter.captured[0].Message != "fixture testdata/user.toml is invalid: not implemented" {
+		t.Fatalf("Failed: failures %v", reporter.captured)
+	}
+}
@@ -0,0 +1,24 @@
+package goblin
+
+import (
+	"flag"
+)
+
+// goblinFlags holds the -goblin.* flags, which are registered with the
+// command line flags of the test binary unless built with the goblin_noflags
+// tag
+var goblinFlags = flag.NewFlagSet("goblin", flag.ContinueOnError)
+
+// RegisterFlags registers the -goblin.* flags with fs, e.g. a FlagSet of an
+// application whose own flags collide with the ones goblin registers with
+// the command line flags by default. Build with -tags goblin_noflags to leave
+// the command line flags alone, then register the goblin flags wherever they
+// fit, or configure suites in code with the options of Goblin instead.
+//
+// Flags are shared by every FlagSet they're registered with, so parsing any
+// of them sets them, and registering them twice with the same FlagSet panics.
+func RegisterFlags(fs *flag.FlagSet) {
+	goblinFlags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+}
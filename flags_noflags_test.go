@@ -0,0 +1,13 @@
+//go:build goblin_noflags
+
+package goblin
+
+import (
+	"flag"
+)
+
+// The tests pass goblin flags on the command line, so register them the way
+// binaries built with goblin_noflags would
+func init() {
+	RegisterFlags(flag.CommandLine)
+}
@@ -0,0 +1,11 @@
+//go:build !goblin_noflags
+
+package goblin
+
+import (
+	"flag"
+)
+
+func init() {
+	RegisterFlags(flag.CommandLine)
+}
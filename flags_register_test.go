@@ -0,0 +1,14 @@
+//go:build !goblin_noflags
+
+package goblin
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestFlagsRegistered(t *testing.T) {
+	if flag.Lookup("goblin.timeout") == nil {
+		t.Fatalf("Failed: flags aren't registered with the command line flags")
+	}
+}
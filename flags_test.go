@@ -0,0 +1,29 @@
+package goblin
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestRegisterFlags(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Duration("timeout", time.Minute, "Timeout of the application")
+	RegisterFlags(fs)
+
+	previous := *timeout
+	defer func() {
+		*timeout = previous
+	}()
+	if err := fs.Parse([]string{"-timeout=1s", "-goblin.timeout=2s"}); err != nil {
+		t.Fatal(err)
+	}
+	if *timeout != 2*time.Second {
+		t.Fatalf("Failed: timeout %s", *timeout)
+	}
+	if f := fs.Lookup("goblin.run"); f == nil || f.Usage == "" {
+		t.Fatalf("Failed: -goblin.run isn't registered")
+	}
+}
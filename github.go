@@ -0,0 +1,75 @@
+package goblin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GitHubReporter writes a GitHub Actions workflow command for each failure, so
+// it's annotated on the line of the failing assertion in the diff of pull
+// requests. Enabled alongside the usual output when running on GitHub Actions,
+// or with -goblin.github-annotations.
+type GitHubReporter struct {
+	w         io.Writer
+	workspace string // Checkout of the repository, which files are relative to
+}
+
+// NewGitHubReporter creates a GitHubReporter writing to w, which GitHub
+// Actions reads workflow commands from when it's the standard output.
+func NewGitHubReporter(w io.Writer) *GitHubReporter {
+	return &GitHubReporter{w: w, workspace: os.Getenv("GITHUB_WORKSPACE")}
+}
+
+func (r *GitHubReporter) BeginDescribe(name string) {}
+
+func (r *GitHubReporter) EndDescribe() {}
+
+func (r *GitHubReporter) Begin() {}
+
+func (r *GitHubReporter) End() {}
+
+func (r *GitHubReporter) Failure(failure *Failure) {
+	r.annotate(failure)
+	for _, additional := range failure.Additional {
+		r.annotate(additional)
+	}
+}
+
+func (r *GitHubReporter) ItTook(duration time.Duration) {}
+
+func (r *GitHubReporter) ItFailed(name string) {}
+
+func (r *GitHubReporter) ItPassed(name string) {}
+
+func (r *GitHubReporter) ItIsPending(name string) {}
+
+func (r *GitHubReporter) ItIsExcluded(name string) {}
+
+// annotate writes the error command of failure
+func (r *GitHubReporter) annotate(failure *Failure) {
+	var properties []string
+	if file, line := failure.File, failure.Line; file != "" {
+		if rel, err := filepath.Rel(r.workspace, file); r.workspace != "" && err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+		properties = append(properties,
+			"file="+escapeGitHubProperty(filepath.ToSlash(file)),
+			fmt.Sprintf("line=%d", line))
+	}
+	properties = append(properties, "title="+escapeGitHubProperty(failure.TestName))
+	fmt.Fprintf(r.w, "::error %s::%s\n", strings.Join(properties, ","), escapeGitHubData(failure.Message))
+}
+
+// escapeGitHubData escapes the message of a workflow command
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes the value of a property of a workflow command
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
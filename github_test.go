@@ -0,0 +1,36 @@
+package goblin
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestGitHubReporter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+	_, file, _, _ := runtime.Caller(0)
+	reporter := NewGitHubReporter(&out)
+	reporter.workspace = filepath.Dir(filepath.Dir(file))
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(multiReporter{&FakeReporter{}, reporter})
+
+	var line int
+	g.Describe("Annotations", func() {
+		g.It("Should point at the assertion, with 100%", func() {
+			_, _, line, _ = runtime.Caller(0)
+			g.Assert("a\nb").Equal("a\nc")
+		}, Timeout(time.Second))
+	})
+
+	rel := filepath.Base(filepath.Dir(file)) + "/github_test.go"
+	expected := fmt.Sprintf("::error file=%s,line=%d,title=Annotations Should point at the assertion%%2C with 100%%25::"+
+		"string does not equal expected%%0A--- expected%%0A+++ actual%%0A a%%0A-c%%0A+b\n", rel, line+1)
+	if out.String() != expected {
+		t.Fatalf("Failed: %q, expected %q", out.String(), expected)
+	}
+}
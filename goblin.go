@@ -2,13 +2,18 @@
 package goblin
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"math/rand"
+	"os"
 	"reflect"
 	"regexp"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"text/template"
 	"time"
 )
 
@@ -23,13 +28,31 @@ type Itable interface {
 	failed(string, []string)
 }
 
-func (g *G) Describe(name string, h func()) {
-	d := &Describe{name: name, h: h, parent: g.parent}
+// Describe declares a block of specs. Decorators passed to Describe apply to
+// every nested block and spec, which may override them.
+func (g *G) Describe(name string, h func(), decorators ...Decorator) {
+	g.describe(name, h, decorators, false)
+}
+
+// FDescribe declares a focused block of specs. When a suite contains focused
+// blocks or specs, only those are run and everything else is reported as
+// excluded.
+func (g *G) FDescribe(name string, h func(), decorators ...Decorator) {
+	g.describe(name, h, decorators, true)
+}
+
+func (g *G) describe(name string, h func(), decorators []Decorator, focused bool) {
+	d := &Describe{name: name, h: h, parent: g.parent, focused: focused}
 
 	if d.parent != nil {
 		d.parent.children = append(d.parent.children, Runnable(d))
 		// Pass down skip status
 		d.skipping = d.parent.skipping
+		d.focused = d.focused || d.parent.focused
+		d.specConfig = d.parent.specConfig.inherit()
+	}
+	for _, decorator := range decorators {
+		decorator.decorate(&d.specConfig)
 	}
 
 	g.parent = d
@@ -38,38 +61,111 @@ func (g *G) Describe(name string, h func()) {
 
 	g.parent = d.parent
 
-	if g.parent == nil && d.hasTests {
+	if g.parent == nil && d.hasTests && *inventoryFile != "" {
+		// Only list the specs, without running anything
+		if err := writeInventory(*inventoryFile, d); err != nil {
+			fmt.Printf("goblin: could not write inventory: %v\n", err)
+			g.t.Fail()
+		}
+		return
+	}
+
+	if g.parent == nil && d.hasTests && *dryRun {
+		// Only list the specs, without running any of them or their hooks
+		if d.hasFocused {
+			d.applyFocus()
+		}
+		d.dryRun(dryRunOutput, 0)
+		return
+	}
+
+	if g.parent == nil && d.hasTests && !g.isInterrupted() {
+		if d.hasFocused {
+			d.applyFocus()
+		}
+		stop := g.handleInterrupts()
+		results := g.collectResults()
 		g.reporter.Begin()
-		if d.run(g) {
+		g.reportSeed()
+		if err := g.startSuite(); err != nil {
+			failSuite(g, d, err)
+			g.t.Fail()
+		} else if d.run(g) || g.abortReason() != "" {
 			g.t.Fail()
 		}
 		g.reporter.End()
+		g.mutex.Lock()
+		g.results = append(g.results, results.root)
+		g.mutex.Unlock()
+		stop()
+
+		if err := timeline.write(*traceFile); err != nil {
+			fmt.Printf("goblin: could not write trace: %v\n", err)
+		}
+
+		if g.isInterrupted() {
+			g.t.Fail()
+			// Exiting skips the cleanups of the Go test, so the suite is torn
+			// down first
+			g.endSuite()
+			for _, err := range tearDownSuiteHooks() {
+				fmt.Printf("goblin: %v\n", err)
+			}
+			exit(1)
+		}
 	}
 }
 
 func (g *G) Timeout(time time.Duration) {
+	g = g.active()
 	g.timeout = time
 	g.timer.Reset(time)
 }
 
 type Describe struct {
+	specConfig
 	name           string
 	h              func()
 	children       []Runnable
-	befores        []func()
-	afters         []func()
-	afterEach      []func()
-	beforeEach     []func()
-	justBeforeEach []func()
+	befores        []hook
+	afters         []hook
+	afterEach      []hook
+	beforeEach     []hook
+	justBeforeEach []hook
+	justAfterEach  []hook
 	hasTests       bool // Flag indicating there are declared tests
 	parent         *Describe
-	skipping       bool // Flag indicating the block is in a Skipped state (may be reset mid-block)
-	hasUnskipped   bool // Flag indicating there are tests to run (not skipped)
+	skipping       bool     // Flag indicating the block is in a Skipped state (may be reset mid-block)
+	hasUnskipped   bool     // Flag indicating there are tests to run (not skipped)
+	hasFocused     bool     // Flag indicating there are focused tests
+	focused        bool     // Flag indicating the block was declared with FDescribe, or nested in one
+	cleanups       cleanups // Registered with Cleanup by the Before and After hooks
 }
 
-func (d *Describe) runBeforeEach() {
+// applyFocus excludes every It that isn't focused, returning whether there are
+// still tests to run
+func (d *Describe) applyFocus() bool {
+	d.hasUnskipped = false
+	for i, r := range d.children {
+		switch child := r.(type) {
+		case *Describe:
+			if child.applyFocus() {
+				d.hasUnskipped = true
+			}
+		case *It:
+			if !child.focused {
+				d.children[i] = Runnable(&Xit{name: child.name, h: child.h, parent: d, reporter: child.reporter, location: child.location, invalid: child.invalid})
+			} else if child.h != nil {
+				d.hasUnskipped = true
+			}
+		}
+	}
+	return d.hasUnskipped
+}
+
+func (d *Describe) runBeforeEach(g *G) {
 	if d.parent != nil {
-		d.parent.runBeforeEach()
+		d.parent.runBeforeEach(g)
 	}
 
 	// Don't run hooks if there's no tests to actually run
@@ -78,13 +174,15 @@ func (d *Describe) runBeforeEach() {
 	}
 
 	for _, b := range d.beforeEach {
-		b()
+		if err := d.runHook(g, `"before each" hook`, b); err != nil {
+			g.Fail(fmt.Sprintf("\"before each\" hook failed: %v", err))
+		}
 	}
 }
 
-func (d *Describe) runJustBeforeEach() {
+func (d *Describe) runJustBeforeEach(g *G) {
 	if d.parent != nil {
-		d.parent.runJustBeforeEach()
+		d.parent.runJustBeforeEach(g)
 	}
 
 	// Don't run hooks if there's no tests to actually run
@@ -93,47 +191,208 @@ func (d *Describe) runJustBeforeEach() {
 	}
 
 	for _, b := range d.justBeforeEach {
-		b()
+		if err := d.runHook(g, `"just before each" hook`, b); err != nil {
+			g.Fail(fmt.Sprintf("\"just before each\" hook failed: %v", err))
+		}
 	}
 }
 
-func (d *Describe) runAfterEach() {
+func (d *Describe) runJustAfterEach(g *G) {
+	// Don't run hooks if there's no tests to actually run
+	if !d.hasUnskipped {
+		return
+	}
+
+	for _, a := range d.justAfterEach {
+		if err := d.runHook(g, `"just after each" hook`, a); err != nil {
+			g.Fail(fmt.Sprintf("\"just after each\" hook failed: %v", err))
+		}
+	}
+
+	if d.parent != nil {
+		d.parent.runJustAfterEach(g)
+	}
+}
+
+func (d *Describe) runAfterEach(g *G) {
 	// Don't run hooks if there's no tests to actually run
 	if !d.hasUnskipped {
 		return
 	}
 
 	for _, a := range d.afterEach {
-		a()
+		if err := d.runHook(g, `"after each" hook`, a); err != nil {
+			g.Fail(fmt.Sprintf("\"after each\" hook failed: %v", err))
+		}
 	}
 
 	if d.parent != nil {
-		d.parent.runAfterEach()
+		d.parent.runAfterEach(g)
 	}
 }
 
+// runHook runs a hook of the block, recording it in the timeline. Assertions
+// failing within the hook are attributed to it, and a panic is returned as its
+// error.
+func (d *Describe) runHook(g *G, name string, h hook) (err error) {
+	defer timeline.begin(g.Shard(), "hook", d.name+" "+name)()
+	g.setHook(name)
+	defer g.setHook("")
+	start := g.clock.Now()
+	defer func() {
+		g.mutex.Lock()
+		g.hookTime += g.clock.Now().Sub(start)
+		g.mutex.Unlock()
+	}()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+	return h()
+}
+
+// runBlockHook runs a Before or After hook of the block. Since those don't
+// run as part of a test, failing assertions within them fail the hook itself.
+func (d *Describe) runBlockHook(g *G, name string, h hook) error {
+	defer timeline.begin(g.Shard(), "hook", d.name+" "+name)()
+	return g.runFailableHook(&hookFailure{scope: d}, h)
+}
+
+// runFailableHook runs a hook which isn't part of a test, returning the
+// failure of the assertions within it, or its panic, as a *hookFailure.
+func (g *G) runFailableHook(failure *hookFailure, h hook) error {
+	g.setCurrentIt(failure)
+	g.mutex.Lock()
+	g.timedOut = false
+	g.mutex.Unlock()
+	stop := g.startSignal(false)
+	defer g.clearSignal()
+
+	go func() {
+		// Failing stops the hook's goroutine, along with the wait for it
+		defer stop.stop()
+		defer failure.recoverPanic()
+		if err := h(); err != nil {
+			failure.failed(err.Error(), nil)
+		}
+	}()
+	<-stop.c
+
+	failure.mu.Lock()
+	defer failure.mu.Unlock()
+	if failure.message == "" {
+		return nil
+	}
+	return &hookFailure{message: failure.message, stack: failure.stack, function: failure.function}
+}
+
+// hookFailure records the failure of a Before or After hook. Only the first
+// failure is kept.
+type hookFailure struct {
+	scope    *Describe // Block the hook belongs to, nil for suite hooks
+	mu       sync.Mutex
+	message  string
+	stack    []string
+	function string // Making the failing assertion
+}
+
+func (f *hookFailure) run(g *G) bool {
+	return false
+}
+
+func (f *hookFailure) failed(msg string, stack []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.message == "" {
+		f.message, f.stack = msg, stack
+		if file, line, ok := failureLocation(stack); ok {
+			f.function = callerFunction(file, line)
+		}
+	}
+}
+
+func (f *hookFailure) Error() string {
+	return f.message
+}
+
+// hookFailed reports the failure of a Before or After hook of the block
+func (d *Describe) hookFailed(g *G, name string, err error) {
+	failure := &Failure{
+		ID:       specID(append(d.path(), name), ""),
+		Message:  err.Error(),
+		Stack:    err.(*hookFailure).stack,
+		TestName: d.name + " " + name,
+	}
+	failure.locate()
+	failure.FuncName = err.(*hookFailure).function
+	g.reporter.ItFailed(name)
+	g.reporter.Failure(failure)
+}
+
 func (d *Describe) run(g *G) bool {
 	failed := false
 	if d.hasTests {
+		defer timeline.begin(g.Shard(), "describe", d.name)()
 		g.reporter.BeginDescribe(d.name)
 
-		if d.hasUnskipped {
+		runHooks := d.hasUnskipped && g.abortReason() == ""
+
+		skipReason := ""
+		if runHooks {
 			for _, b := range d.befores {
-				b()
+				if err := d.runBlockHook(g, `"before all" hook`, b); err != nil {
+					// Nothing nested can be trusted to run against the state the
+					// hook left behind
+					failed = true
+					d.hookFailed(g, `"before all" hook`, err)
+					skipReason = fmt.Sprintf("\"before all\" hook of %q failed", d.name)
+					break
+				}
 			}
 		}
 
-		for _, r := range d.children {
-			if r.run(g) {
+		children := d.shuffled(g)
+		for i := 0; i < len(children); i++ {
+			r := children[i]
+			// Stop scheduling tests once interrupted
+			if g.isInterrupted() {
+				break
+			}
+			if reason := g.abortReason(); reason != "" {
+				skip(g, r, "suite aborted: "+reason)
+				continue
+			}
+			if skipReason != "" {
+				skip(g, r, skipReason)
+				continue
+			}
+			if batch := d.parallelBatch(g, children[i:]); batch != nil {
+				i += len(batch) - 1
+				if g.runParallel(batch) {
+					failed = true
+				}
+				continue
+			}
+			if g.runSpec(r) {
 				failed = true
+				if d.failFast {
+					skipReason = fmt.Sprintf("a previous spec of %q failed", d.name)
+				}
 			}
 		}
 
-		if d.hasUnskipped {
+		if runHooks {
 			for _, a := range d.afters {
-				a()
+				if err := d.runBlockHook(g, `"after all" hook`, a); err != nil {
+					failed = true
+					d.hookFailed(g, `"after all" hook`, err)
+				}
 			}
 		}
+		if d.runCleanups(g) {
+			failed = true
+		}
 
 		g.reporter.EndDescribe()
 	}
@@ -142,51 +401,163 @@ func (d *Describe) run(g *G) bool {
 }
 
 type Failure struct {
+	ID       string // Stable identifier of the failed spec
 	Stack    []string
 	TestName string
 	Message  string
+	File     string // Where the failing assertion was made, the first entry of Stack outside of goblin
+	Line     int
+	FuncName string      // Function making the failing assertion, e.g. "github.com/you/pkg.TestNumbers.func1"
+	Output   string      // Output captured while the test ran
+	Logs     []LogRecord // Log messages captured while the test ran
+	// Failures recorded after the first one, e.g. by other goroutines of the
+	// test
+	Additional []*Failure
 }
 
 type It struct {
+	specConfig
 	h         interface{}
 	name      string
 	parent    *Describe
 	failure   *Failure
 	failureMu sync.RWMutex
 	reporter  Reporter
+	focused   bool
+	output    *specOutput
+	location  sourceLocation
+	invalid   string   // Why the handler can't be run, if it can't
+	warnings  []string // Recorded with Warn, guarded by failureMu
+	sealed    bool     // Whether the failure was reported, so no more can be added to it
+	cleanups  cleanups // Registered with Cleanup while the spec runs
+	measured  *Stats   // Sampled by a spec declared with Measure, guarded by failureMu
 	// isAsync   bool  // This seems to be unused
 }
 
 func (it *It) run(g *G) bool {
-	g.currentIt = it
+	g.setCurrentIt(it)
 
 	if it.h == nil {
 		g.reporter.ItIsPending(it.name)
 		return false
 	}
 
-	runIt(g, it)
+	if it.invalid != "" {
+		it.failed(it.invalid, []string{it.location.String()})
+		g.reporter.ItFailed(it.name)
+		g.reporter.Failure(it.failure)
+		return true
+	}
+
+	defer timeline.begin(g.Shard(), "test", it.parent.name+" "+it.name)()
 
+	it.started(g)
+	g.mutex.Lock()
+	g.hookTime = 0
+	g.mutex.Unlock()
+	start := g.clock.Now()
+	var endSample func() *MemoryUsage
+	if *memStats {
+		endSample = memorySample()
+	}
+	stopProfiles := it.startProfiles()
 	failed := false
-	it.failureMu.RLock()
-	if it.failure != nil {
+	retries := 0
+	for attempt := 0; ; attempt++ {
+		retries = attempt
+		runIt(g, it)
+
+		it.failureMu.Lock()
+		failure := it.failure
+		failed = failure != nil
+		if failed && attempt < it.retries && g.abortReason() == "" {
+			// Clear the failure so the next attempt starts fresh
+			it.failure = nil
+			it.failureMu.Unlock()
+
+			delay := it.backoff << uint(attempt)
+			if r, ok := g.reporter.(RetryReporter); ok {
+				r.ItAttemptFailed(it.name, attempt+1, failure, delay)
+			}
+			if delay > 0 {
+				<-g.runnerTimer(delay).C()
+			}
+			continue
+		}
+		it.sealed = true
+		it.failureMu.Unlock()
+		break
+	}
+	stopProfiles()
+	duration := g.clock.Now().Sub(start)
+	if g.excludeHookTime {
+		g.mutex.Lock()
+		duration -= g.hookTime
+		g.mutex.Unlock()
+	}
+	var memory *MemoryUsage
+	if endSample != nil {
+		memory = endSample()
+	}
+
+	failed = it.checkBudget(duration, failed)
+	failed = it.reportWarnings(g, failed)
+
+	if it.xfail != "" {
+		if failed {
+			if r, ok := g.reporter.(ExpectedFailureReporter); ok {
+				r.ItFailedAsExpected(it.name, it.xfail)
+			} else {
+				g.reporter.ItPassed(it.name)
+			}
+			it.report(g, false, duration, retries+1, memory)
+			it.recordStats(start, "xfail", duration, retries)
+			return false
+		}
+		it.failed(fmt.Sprintf("expected failure now passes — remove the marker (%s)", it.xfail), []string{it.location.String()})
 		failed = true
 	}
-	it.failureMu.RUnlock()
 
 	if failed {
+		it.failure.Output = it.output.String()
+		it.failure.Logs = it.output.records()
 		g.reporter.ItFailed(it.name)
 		g.reporter.Failure(it.failure)
+		if *pauseOnFailure {
+			pause(it.failure)
+		}
 	} else {
 		g.reporter.ItPassed(it.name)
+		if r, ok := g.reporter.(OutputReporter); ok && testing.Verbose() {
+			if output := it.output.String(); output != "" {
+				r.ItOutput(it.name, output)
+			}
+		}
 	}
+	it.reportMeasurement(g)
+	it.report(g, failed, duration, retries+1, memory)
+	status := "passed"
+	if failed {
+		status = "failed"
+	}
+	it.recordStats(start, status, duration, retries)
 	return failed
 }
 
 func (it *It) failed(msg string, stack []string) {
 	it.failureMu.Lock()
 	defer it.failureMu.Unlock()
-	it.failure = &Failure{Stack: stack, Message: msg, TestName: it.parent.name + " " + it.name}
+	if it.source != "" {
+		stack = append([]string{it.source}, stack...)
+	}
+	failure := &Failure{ID: it.id(), Stack: stack, Message: msg, TestName: it.parent.name + " " + it.name}
+	failure.locate()
+	if it.failure == nil {
+		it.failure = failure
+	} else if !it.sealed {
+		// The first failure wins, e.g. among the goroutines of the spec
+		it.failure.Additional = append(it.failure.Additional, failure)
+	}
 }
 
 type Xit struct {
@@ -195,11 +566,26 @@ type Xit struct {
 	parent   *Describe
 	failure  *Failure
 	reporter Reporter
+	location sourceLocation
+	invalid  string // Why the handler can't be run, if it can't
 	// isAsync  bool  // This seems to be unused
 }
 
 func (xit *Xit) run(g *G) bool {
-	g.currentIt = xit
+	g.setCurrentIt(xit)
+
+	if xit.invalid != "" {
+		failure := &Failure{
+			ID:       specID(append(xit.parent.path(), xit.name), xit.location.file),
+			Stack:    []string{xit.location.String()},
+			Message:  xit.invalid,
+			TestName: xit.parent.name + " " + xit.name,
+		}
+		failure.locate()
+		g.reporter.ItFailed(xit.name)
+		g.reporter.Failure(failure)
+		return true
+	}
 
 	g.reporter.ItIsExcluded(xit.name)
 	return false
@@ -212,104 +598,428 @@ func (xit *Xit) failed(msg string, stack []string) {
 func parseFlags() {
 	//Flag parsing
 	flag.Parse()
+	randomSeed = time.Now().UnixNano()
 	if *regexParam != "" {
 		runRegex = regexp.MustCompile(*regexParam)
 	} else {
 		runRegex = nil
 	}
+	if *skipParam != "" {
+		skipRegex = regexp.MustCompile(*skipParam)
+	} else {
+		skipRegex = nil
+	}
+	if *labelFilterParam != "" {
+		filter, err := parseLabelFilter(*labelFilterParam)
+		if err != nil {
+			panic(err)
+		}
+		labelFilterExpr = filter
+	} else {
+		labelFilterExpr = nil
+	}
+	if *locationFormat != "" {
+		locationTemplate = template.Must(template.New("location").Parse(*locationFormat))
+	} else {
+		locationTemplate = nil
+	}
+	if *profileSpec != "" {
+		profileSpecRegex = regexp.MustCompile(*profileSpec)
+	} else {
+		profileSpecRegex = nil
+	}
+	if *traceFile != "" && timeline == nil {
+		timeline = newTracer()
+	}
 }
 
 var doParseOnce sync.Once
-var timeout = flag.Duration("goblin.timeout", 5*time.Second, "Sets default timeouts for all tests")
-var isTty = flag.Bool("goblin.tty", true, "Sets the default output format (color / monochrome)")
-var regexParam = flag.String("goblin.run", "", "Runs only tests which match the supplied regex")
+var timeout = goblinFlags.Duration("goblin.timeout", 5*time.Second, "Sets default timeouts for all tests")
+var unicodeOutput = goblinFlags.Bool("goblin.unicode", true, "Uses Unicode glyphs in the output, or only ASCII when false")
+var isTty = goblinFlags.Bool("goblin.tty", colorOutput(isTerminal(os.Stdout)), "Sets the default output format (color / monochrome), color when stdout is a terminal unless NO_COLOR is set, or when FORCE_COLOR is")
+var themeName = goblinFlags.String("goblin.theme", "default", "Colors the output with a built-in theme (default / high-contrast / dim)")
+var regexParam = goblinFlags.String("goblin.run", "", "Runs only tests which match the supplied regex")
+var skipParam = goblinFlags.String("goblin.skip", "", "Runs only tests which don't match the supplied regex")
+var pollProgressAfter = goblinFlags.Duration("goblin.poll-progress-after", 0, "Periodically prints the progress of tests running for longer than this (0 disables)")
+var traceFile = goblinFlags.String("goblin.trace", "", "Writes a timeline of the run to this file in the Chrome trace event format")
+var pauseOnFailure = goblinFlags.Bool("goblin.pause-on-failure", false, "Pauses after each failing test until Enter is pressed, when run from a terminal")
+var labelFilterParam = goblinFlags.String("goblin.label-filter", "", "Runs only tests whose labels match the supplied expression, e.g. 'integration && !slow'")
+var warningsAsErrors = goblinFlags.Bool("goblin.warnings-as-errors", false, "Fails tests which record warnings")
+var budgetsAsWarnings = goblinFlags.Bool("goblin.budgets-as-warnings", false, "Warns instead of failing tests which exceed their Budget")
+var failuresOnly = goblinFlags.Bool("goblin.failures-only", false, "Only prints the failing tests, under their Describe blocks, along with the summary")
+var slowReport = goblinFlags.Int("goblin.slow-report", 0, "Lists this many of the slowest tests in the summary of the run")
+var statsFile = goblinFlags.String("goblin.stats-file", "", "Appends a CSV row with the status, duration and retries of each test to this file")
+var githubAnnotations = goblinFlags.Bool("goblin.github-annotations", os.Getenv("GITHUB_ACTIONS") == "true", "Annotates failures on GitHub Actions, enabled by default when running on it")
+var summaryFile = goblinFlags.String("goblin.summary", "", "Writes a JSON summary of the run to this file, alongside the usual output")
+var test2jsonFile = goblinFlags.String("goblin.test2json", "", "Writes an event for each test in the go test -json format to this file, alongside the usual output")
+var junitFile = goblinFlags.String("goblin.junit", "", "Writes the results as JUnit XML to this file, alongside the usual output")
+var parallelWorkers = goblinFlags.Int("goblin.parallel", 1, "Runs up to this many tests at the same time, or as many as there are CPUs if 0")
+var randomizeSpecs = goblinFlags.Bool("goblin.randomize", false, "Runs the tests of each block in a random order")
+var randomizeAll = goblinFlags.Bool("goblin.randomize-all", false, "Runs nested blocks in a random order too, along with the tests")
+var seedParam = goblinFlags.Int64("goblin.seed", 0, "Seeds the random order of the tests, to reproduce the order of a previous run")
+var format = goblinFlags.String("goblin.format", "detailed", "Sets the output format (detailed / dot / quiet / json / tap / teamcity)")
+var locationFormat = goblinFlags.String("goblin.location-format", "", "Formats failure messages with their location, e.g. '{{.File}}:{{.Line}}: {{.Message}}'")
+var profileSpec = goblinFlags.String("goblin.profile-spec", "", "Captures a CPU profile of each test matching the supplied regex")
+var memStats = goblinFlags.Bool("goblin.memstats", false, "Samples the memory allocated by each test for structured reports")
+var dryRun = goblinFlags.Bool("goblin.dry-run", false, "Lists the declared tests instead of running them")
+var inventoryFile = goblinFlags.String("goblin.inventory", "", "Writes the declared specs to this file as JSON instead of running them")
+var updateSnapshots = goblinFlags.Bool("goblin.update-snapshots", false, "Rewrites the snapshots compared by AssertSnapshot with the current values")
 var runRegex *regexp.Regexp
+var skipRegex *regexp.Regexp
 
-func Goblin(t *testing.T, arguments ...string) *G {
+func Goblin(t *testing.T, options ...Option) *G {
 	doParseOnce.Do(func() {
 		parseFlags()
 	})
 
-	g := &G{t: t, timeout: *timeout}
+	g := &G{t: t, timeout: *timeout, defaultTimeout: *timeout, clock: realClock{}}
+	g.Parallel(*parallelWorkers)
+	if *randomizeSpecs || *randomizeAll {
+		seed := *seedParam
+		if seed == 0 {
+			seed = randomSeed
+		}
+		g.randomize(seed, *randomizeAll)
+	}
+	theme, ok := themes[*themeName]
+	if !ok {
+		panic(fmt.Sprintf("Unknown -goblin.theme %q, expected default, high-contrast or dim.", *themeName))
+	}
 	var fancy TextFancier
-	if *isTty {
-		fancy = &TerminalFancier{}
+	if *isTty && enableColors(os.Stdout) {
+		fancy = theme
 	} else {
 		fancy = &Monochrome{}
 	}
 
-	g.reporter = Reporter(&DetailedReporter{fancy: fancy})
+	switch *format {
+	case "", "detailed":
+		g.reporter = Reporter(&DetailedReporter{fancy: fancy, width: terminalWidth()})
+	case "dot":
+		g.reporter = Reporter(NewDotReporter(os.Stdout, fancy))
+	case "quiet":
+		g.reporter = Reporter(NewQuietReporter(os.Stdout, fancy))
+	case "json":
+		g.reporter = Reporter(NewJSONReporter(os.Stdout))
+	case "tap":
+		g.reporter = Reporter(NewTAPReporter(os.Stdout))
+	case "teamcity":
+		g.reporter = Reporter(NewTeamCityReporter(os.Stdout))
+	default:
+		panic(fmt.Sprintf("Unknown -goblin.format %q, expected detailed, dot, quiet, json, tap or teamcity.", *format))
+	}
+	if r, ok := g.reporter.(interface{ SetSlowest(int) }); ok && *slowReport > 0 {
+		r.SetSlowest(*slowReport)
+	}
+	if r, ok := g.reporter.(interface{ SetFailuresOnly(bool) }); ok && *failuresOnly {
+		r.SetFailuresOnly(true)
+	}
+	if *junitFile != "" {
+		g.fileReporters = append(g.fileReporters, NewJUnitReporter(*junitFile, t.Name()))
+	}
+	if *test2jsonFile != "" {
+		if w, err := openTest2JSONFile(*test2jsonFile); err != nil {
+			fmt.Printf("goblin: could not write test2json events: %v\n", err)
+		} else {
+			g.fileReporters = append(g.fileReporters, NewTest2JSONReporter(w, t.Name()))
+		}
+	}
+	if *summaryFile != "" {
+		g.fileReporters = append(g.fileReporters, NewSummaryReporter(*summaryFile, t.Name()))
+	}
+	if *githubAnnotations {
+		g.fileReporters = append(g.fileReporters, NewGitHubReporter(os.Stdout))
+	}
+	g.SetReporter(g.reporter)
+	for _, option := range options {
+		option(g)
+	}
 	return g
 }
 
 func runIt(g *G, it *It) {
 	g.mutex.Lock()
 	g.timedOut = false
+	g.hook = ""
+	g.done = nil
+	g.waiting = nil
 	g.mutex.Unlock()
-	g.timer = time.NewTimer(g.timeout)
-	g.shouldContinue = make(chan bool)
-	if call, ok := it.h.(func()); ok {
-		// the test is synchronous
-		go func(c chan bool) {
-			it.parent.runBeforeEach()
-			it.parent.runJustBeforeEach()
-			timeTrack(g, func() { call() })
-			it.parent.runAfterEach()
-			c <- true
-		}(g.shouldContinue)
-	} else if call, ok := it.h.(func(Done)); ok {
-		doneCalled := 0
-		go func(c chan bool) {
-			it.parent.runBeforeEach()
-			it.parent.runJustBeforeEach()
+	if it.timeout > 0 {
+		g.timeout = it.timeout
+	}
+	it.output = &specOutput{}
+	defer g.releaseSpecPorts()
+	g.mutex.Lock()
+	g.output = it.output
+	g.mutex.Unlock()
+	defer func() {
+		g.mutex.Lock()
+		g.output = nil
+		g.mutex.Unlock()
+	}()
+	if g.captureLogs {
+		defer captureLogs(it.output)()
+	}
+	g.startProgress(it)
+	defer g.stopProgress()
+	if *pollProgressAfter > 0 {
+		defer g.pollProgress(*pollProgressAfter)()
+	}
+	g.timer = g.runnerTimer(g.timeout)
+	defer g.timer.Stop()
+	_, async := it.h.(func(Done))
+	var afterOnce sync.Once // Runs the after hooks of an async spec
+	stop := g.startSignal(async)
+	defer g.clearSignal()
+	call, ok := it.h.(func())
+	cancel := func() {}
+	if h, withContext := it.h.(func(context.Context)); withContext {
+		var ctx context.Context
+		ctx, cancel = g.specContext()
+		call, ok = func() { h(ctx) }, true
+	}
+	if ok {
+		// the test is synchronous, and ends when its goroutine does, even
+		// when failing. Goroutines it starts only stop themselves by failing,
+		// so their failures are attributed to it as long as it waits for them.
+		go func() {
+			defer stop.stop()
+			g.trackGoroutine()
+			g.register()
+			// Deferred so they also run once a failure exits the goroutine
+			defer it.parent.runAfterEach(g)
+			defer it.parent.runJustAfterEach(g)
+			it.parent.runBeforeEach(g)
+			it.parent.runJustBeforeEach(g)
 			timeTrack(g, func() {
-				call(func(msg ...interface{}) {
-					if len(msg) > 0 {
-						g.Fail(msg)
-					} else {
-						doneCalled++
-						if doneCalled > 1 {
-							g.Fail("Done called multiple times")
-						}
-						it.parent.runAfterEach()
-						c <- true
-					}
+				defer it.recoverPanic(nil)
+				call()
+			})
+		}()
+	} else if call, ok := it.h.(func(Done)); ok {
+		var doneCalled int32
+		done := Done(func(msg ...interface{}) {
+			if len(msg) > 0 {
+				g.Fail(msg)
+			} else {
+				if atomic.AddInt32(&doneCalled, 1) > 1 {
+					g.Fail("Done called multiple times")
+				}
+				afterOnce.Do(func() {
+					it.parent.runJustAfterEach(g)
+					it.parent.runAfterEach(g)
 				})
+				stop.stop()
+			}
+		})
+		g.mutex.Lock()
+		g.done = done
+		g.mutex.Unlock()
+		go func() {
+			g.trackGoroutine()
+			g.register()
+			it.parent.runBeforeEach(g)
+			it.parent.runJustBeforeEach(g)
+			var panicked bool
+			timeTrack(g, func() {
+				defer it.recoverPanic(&panicked)
+				call(done)
 			})
-		}(g.shouldContinue)
-	} else {
-		panic("Not implemented.")
+			if panicked {
+				// done won't be called
+				stop.stop()
+			}
+		}()
 	}
 	select {
-	case <-g.shouldContinue:
-	case <-g.timer.C:
-		//Set to nil as it shouldn't continue
-		g.shouldContinue = nil
+	case <-stop.c:
+	case <-g.timer.C():
+		g.mutex.Lock()
 		g.timedOut = true
-		g.Fail(fmt.Sprintf("Test exceeded %s", g.timeout))
+		g.mutex.Unlock()
+		msg := fmt.Sprintf("Test exceeded %s", g.timeout)
+		if outstanding := g.outstandingSignals(); outstanding != "" {
+			msg += ", " + outstanding
+		}
+		g.Fail(msg)
 	}
+	if async {
+		// Failing or timing out, the spec doesn't call done, so its after hooks
+		// are run here
+		hooks := g.startSignal(false)
+		go func() {
+			defer hooks.stop()
+			g.register()
+			afterOnce.Do(func() {
+				it.parent.runJustAfterEach(g)
+				it.parent.runAfterEach(g)
+			})
+		}()
+		timer := g.runnerTimer(g.timeout)
+		select {
+		case <-hooks.c:
+		case <-timer.C():
+			it.failed(fmt.Sprintf("after each hooks exceeded %s", g.timeout), nil)
+		}
+		timer.Stop()
+	}
+	cancel()
 	// Reset timeout value
-	g.timeout = *timeout
+	g.timeout = g.defaultTimeout
+	it.runCleanups(g)
 }
 
 type G struct {
-	t              *testing.T
-	parent         *Describe
-	currentIt      Itable
-	timeout        time.Duration
-	reporter       Reporter
-	timedOut       bool
-	shouldContinue chan bool
-	mutex          sync.Mutex
-	timer          *time.Timer
+	t               *testing.T
+	parent          *Describe
+	currentIt       Itable // Spec or hook being run, guarded by mutex
+	timeout         time.Duration
+	reporter        Reporter
+	timedOut        bool
+	shouldContinue  *stopSignal // Stopped once the running spec or hook ends or fails, guarded by mutex
+	mutex           sync.Mutex
+	timer           Timer
+	clock           Clock
+	beforeSuite     []suiteHook
+	afterSuite      []suiteHook
+	suiteStarted    bool
+	suiteEnded      bool
+	usesSuiteHooks  bool // Whether the suite holds a reference to the hooks of the test binary
+	beforeShard     []func(int)
+	afterShard      []func(int)
+	shards          []int         // Shards which have been started, in order
+	shard           int           // Shard a fork runs its spec in
+	interrupted     int32         // Set atomically once the run is interrupted
+	interruptions   chan struct{} // Closed once the run is interrupted, guarded by mutex
+	running         *progress
+	progressMu      sync.Mutex
+	captureLogs     bool
+	output          *specOutput   // Output of the running spec, guarded by mutex
+	hook            string        // Name of the running BeforeEach or AfterEach hook, guarded by mutex
+	hookTime        time.Duration // Spent in the BeforeEach and AfterEach hooks of the running spec, guarded by mutex
+	excludeHookTime bool          // Whether the durations of specs exclude hookTime
+	done            Done          // Done of the running async spec, guarded by mutex
+	waiting         *waitGroup    // Signals the running spec waits for, guarded by mutex
+	ports           []int         // Ports reserved by the running spec, guarded by mutex
+	comparators     *comparators  // Registered for the suite, guarded by mutex
+	aborted         string        // Why the run was aborted with AbortSuite, guarded by mutex
+	specT           *testing.T    // Subtest running the current spec, guarded by mutex
+	workers         int           // How many specs may run at the same time
+	root            *G            // G this one was forked from to run a spec in parallel
+	forks           map[string]*G // Forks running specs in parallel by goroutine, guarded by forksMu
+	forksMu         sync.Mutex
+	defaultTimeout  time.Duration                // Of specs without a Timeout, -goblin.timeout unless set with WithTimeout
+	runRegex        *regexp.Regexp               // Set with WithRegex, replacing -goblin.run
+	results         []*SuiteResult               // Of the top-level blocks run so far, guarded by mutex
+	collector       *resultsReporter             // Builds the results of the running top-level block
+	collecting      Reporter                     // Reporter once the collector was added to it
+	fixtures        map[fixtureKey]reflect.Value // Loaded with LoadFixture, guarded by mutex
+	fileReporters   []Reporter                   // Selected with flags, such as -goblin.junit, reporting alongside any reporter
+	parallelRunning int32                        // Set atomically while specs run in parallel
+	order           *rand.Rand                   // Shuffles the specs of each block when randomizing
+	seed            int64                        // Seed order was created with
+	randomizeAll    bool                         // Whether blocks are shuffled along with specs
+}
+
+// stopSignal is closed when the running spec or hook should no longer be
+// waited for. Stopping it more than once is harmless, so any number of
+// goroutines of a spec may fail it concurrently.
+type stopSignal struct {
+	c    chan struct{}
+	once sync.Once
+	// Whether a failure ends the spec, rather than only the goroutine failing,
+	// for async specs which don't end when their handler returns
+	stopOnFailure bool
+}
+
+func (s *stopSignal) stop() {
+	s.once.Do(func() {
+		close(s.c)
+	})
+}
+
+// startSignal creates the stopSignal of a spec or hook about to run
+func (g *G) startSignal(stopOnFailure bool) *stopSignal {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.shouldContinue = &stopSignal{c: make(chan struct{}), stopOnFailure: stopOnFailure}
+	return g.shouldContinue
+}
+
+func (g *G) clearSignal() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.shouldContinue = nil
+}
+
+func (g *G) setCurrentIt(it Itable) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.currentIt = it
+}
+
+// current returns the spec or hook being run, along with its stopSignal
+func (g *G) current() (Itable, *stopSignal) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.currentIt, g.shouldContinue
 }
 
+func (g *G) setHook(name string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.hook = name
+}
+
+func (g *G) runningHook() string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.hook
+}
+
+// SetReporter reports the results to r. The reports selected with
+// -goblin.junit, -goblin.summary, -goblin.test2json and
+// -goblin.github-annotations are still written alongside.
 func (g *G) SetReporter(r Reporter) {
-	g.reporter = r
+	if len(g.fileReporters) == 0 {
+		g.reporter = r
+		return
+	}
+	g.reporter = append(multiReporter{r}, g.fileReporters...)
 }
 
+// It declares a spec. The handler may be accompanied by Decorators, such as
+// Label, Timeout or Retry, in any position. A handler taking a
+// context.Context receives one which is cancelled once the spec times out or
+// the run is interrupted, so it can stop instead of running on in the
+// background.
 func (g *G) It(name string, h ...interface{}) {
-	if matchesRegex(name) {
+	h, decorators := splitDecorators(h)
+	g.it(name, h, decorators, false)
+}
+
+// Fit declares a focused spec. When a suite contains focused blocks or specs,
+// only those are run and everything else is reported as excluded.
+func (g *G) Fit(name string, h ...interface{}) {
+	h, decorators := splitDecorators(h)
+	g.it(name, h, decorators, true)
+}
+
+// ItRetry declares a flaky spec, which runs up to attempts times and is only
+// reported as failed if every attempt fails. It is a shorthand for passing the
+// FlakeAttempts decorator to It.
+func (g *G) ItRetry(name string, attempts int, h ...interface{}) {
+	g.It(name, append(h, FlakeAttempts(attempts))...)
+}
+
+// ItTimeout declares a spec which fails if it runs for longer than timeout. It
+// is a shorthand for passing the Timeout decorator to It.
+func (g *G) ItTimeout(name string, timeout time.Duration, h ...interface{}) {
+	g.It(name, append(h, Timeout(timeout))...)
+}
+
+func (g *G) it(name string, h []interface{}, decorators []Decorator, focused bool) {
+	if g.matches(name) {
 		if g.parent == nil {
 			panic(fmt.Sprintf("It(\"%s\") block should be written inside Describe() block.", name))
 		}
@@ -320,29 +1030,50 @@ func (g *G) It(name string, h ...interface{}) {
 			return
 		}
 
-		it := &It{name: name, parent: g.parent, reporter: g.reporter}
+		it := &It{name: name, parent: g.parent, reporter: g.reporter, focused: focused || g.parent.focused, location: callerLocation()}
+		it.specConfig = g.parent.specConfig.inherit()
+		for _, d := range decorators {
+			d.decorate(&it.specConfig)
+		}
+		if !matchesLabels(it.labels) {
+			return
+		}
 
 		notifyParents(g.parent)
 		if len(h) > 0 {
 			it.h = h[0]
+			it.invalid = checkHandler("It", it.h)
 			notifyUnskipped(g.parent)
 		}
+		if it.focused {
+			notifyFocused(g.parent)
+		}
 		g.parent.children = append(g.parent.children, Runnable(it))
 	}
 }
 
 func (g *G) Xit(name string, h ...interface{}) {
-	if matchesRegex(name) {
-		xit := &Xit{name: name, parent: g.parent, reporter: g.reporter}
+	h, _ = splitDecorators(h)
+	if g.matches(name) {
+		xit := &Xit{name: name, parent: g.parent, reporter: g.reporter, location: callerLocation()}
 		notifyParents(g.parent)
 		if len(h) > 0 {
 			xit.h = h[0]
+			xit.invalid = checkHandler("Xit", xit.h)
 		}
 		g.parent.children = append(g.parent.children, Runnable(xit))
 	}
 }
 
-func matchesRegex(value string) bool {
+// matches returns whether the spec or block named value runs, according to
+// -goblin.skip, and WithRegex or -goblin.run
+func (g *G) matches(value string) bool {
+	if skipRegex != nil && skipRegex.MatchString(value) {
+		return false
+	}
+	if g.runRegex != nil {
+		return g.runRegex.MatchString(value)
+	}
 	if runRegex != nil {
 		return runRegex.MatchString(value)
 	}
@@ -357,6 +1088,14 @@ func notifyParents(d *Describe) {
 	}
 }
 
+// notifyFocused marks the parent Describe as having focused tests
+func notifyFocused(d *Describe) {
+	d.hasFocused = true
+	if d.parent != nil {
+		notifyFocused(d.parent)
+	}
+}
+
 // notifyUnskipped marks the parent Describe as having unskipped tests
 func notifyUnskipped(d *Describe) {
 	d.hasUnskipped = true
@@ -365,45 +1104,138 @@ func notifyUnskipped(d *Describe) {
 	}
 }
 
-func (g *G) Before(h func()) {
-	g.parent.befores = append(g.parent.befores, h)
+// checkHandler returns why h can't be run as the handler of a spec, or an
+// empty string if it can
+func checkHandler(kind string, h interface{}) string {
+	switch h.(type) {
+	case func(), func(Done), func(context.Context):
+		return ""
+	}
+	return fmt.Sprintf("%s(%T) handler should be a func(), func(Done) or func(context.Context).", kind, h)
+}
+
+// hook is a Before, After, BeforeEach, JustBeforeEach, JustAfterEach or
+// AfterEach handler
+type hook func() error
+
+// toHook converts a hook handler, which must be either a func() or a
+// func() error, to a hook
+func toHook(kind string, h interface{}) hook {
+	switch fn := h.(type) {
+	case func():
+		return func() error {
+			fn()
+			return nil
+		}
+	case func() error:
+		return fn
+	}
+	panic(fmt.Sprintf("%s(%T) handler should be a func() or func() error.", kind, h))
+}
+
+// Before registers a hook run once before the tests of the block. The hook may
+// be a func() or a func() error; if it returns an error, every test of the
+// block fails with it.
+func (g *G) Before(h interface{}) {
+	g.parent.befores = append(g.parent.befores, toHook("Before", h))
+}
+
+// BeforeEach registers a hook run before each test of the block. The hook may
+// be a func() or a func() error; if it returns an error, the test fails.
+func (g *G) BeforeEach(h interface{}) {
+	g.parent.beforeEach = append(g.parent.beforeEach, toHook("BeforeEach", h))
 }
 
-func (g *G) BeforeEach(h func()) {
-	g.parent.beforeEach = append(g.parent.beforeEach, h)
+// JustBeforeEach registers a hook run before each test of the block, after
+// all the BeforeEach hooks. The hook may be a func() or a func() error; if it
+// returns an error, the test fails.
+func (g *G) JustBeforeEach(h interface{}) {
+	g.parent.justBeforeEach = append(g.parent.justBeforeEach, toHook("JustBeforeEach", h))
 }
 
-func (g *G) JustBeforeEach(h func()) {
-	g.parent.justBeforeEach = append(g.parent.justBeforeEach, h)
+// JustAfterEach registers a hook run after each test of the block, before all
+// the AfterEach hooks, e.g. to capture diagnostics while the system under test
+// is still intact. Like AfterEach, it also runs once the test failed. The hook
+// may be a func() or a func() error; if it returns an error, the test fails.
+func (g *G) JustAfterEach(h interface{}) {
+	g.parent.justAfterEach = append(g.parent.justAfterEach, toHook("JustAfterEach", h))
 }
 
-func (g *G) After(h func()) {
-	g.parent.afters = append(g.parent.afters, h)
+// After registers a hook run once after the tests of the block. The hook may
+// be a func() or a func() error; a returned error is reported as a failure of
+// the block.
+func (g *G) After(h interface{}) {
+	g.parent.afters = append(g.parent.afters, toHook("After", h))
 }
 
-func (g *G) AfterEach(h func()) {
-	g.parent.afterEach = append(g.parent.afterEach, h)
+// AfterEach registers a hook run after each test of the block. The hook may be
+// a func() or a func() error; if it returns an error, the test fails.
+func (g *G) AfterEach(h interface{}) {
+	g.parent.afterEach = append(g.parent.afterEach, toHook("AfterEach", h))
 }
 
 func (g *G) Assert(src interface{}) *Assertion {
-	return &Assertion{src: src, fail: g.Fail}
+	fail := g.assertionFail()
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return &Assertion{src: src, fail: fail, comparators: g.comparators}
+}
+
+// assertionFail returns how assertions fail the running spec: without
+// stopping it if it's decorated with Soft
+func (g *G) assertionFail() func(interface{}) {
+	if g.soft() {
+		return g.softFail
+	}
+	return g.Fail
+}
+
+// soft returns whether the running spec is decorated with Soft, outside of
+// its hooks
+func (g *G) soft() bool {
+	g = g.active()
+	it, _ := g.current()
+	spec, ok := it.(*It)
+	return ok && spec.soft && g.runningHook() == ""
+}
+
+// softFail fails the running spec without stopping it
+func (g *G) softFail(error interface{}) {
+	g.errorCommon(fmt.Sprintf("%v", error), false)
 }
 
 func timeTrack(g *G, call func()) {
-	t := time.Now()
+	t := g.clock.Now()
 	defer func() {
-		g.reporter.ItTook(time.Since(t))
+		g.reporter.ItTook(g.clock.Now().Sub(t))
 	}()
 	call()
 }
 
 func (g *G) errorCommon(msg string, fatal bool) {
-	if g.currentIt == nil {
+	g = g.active()
+	it, stop := g.current()
+	if it == nil {
+		if atomic.LoadInt32(&g.parallelRunning) > 0 {
+			// There's no telling which spec to fail, so the Go test fails
+			reason := "asserts of specs running in parallel should be made from their goroutine, or goroutines it starts"
+			if !creatorsKnown() {
+				reason = "asserts of specs running in parallel should be made from their goroutine before Go 1.21, which tells the goroutines they start apart"
+			}
+			g.t.Errorf("goblin: %s: %s", reason, msg)
+			if fatal {
+				runtime.Goexit()
+			}
+			return
+		}
 		panic("Asserts should be written inside an It() block.")
 	}
-	g.currentIt.failed(msg, ResolveStack(9))
-	if g.shouldContinue != nil {
-		g.shouldContinue <- true
+	if hook := g.runningHook(); hook != "" {
+		msg = hook + " failed: " + msg
+	}
+	it.failed(msg, ResolveStack(9))
+	if fatal && stop != nil && stop.stopOnFailure {
+		stop.stop()
 	}
 
 	if fatal {
@@ -530,3 +1362,29 @@ func indirect(a interface{}) interface{} {
 	}
 	return v.Interface()
 }
+
+// skip reports r, and everything nested in it, as skipped for reason without
+// running anything
+func skip(g *G, r Runnable, reason string) {
+	switch r := r.(type) {
+	case *Describe:
+		if !r.hasTests {
+			return
+		}
+		g.reporter.BeginDescribe(r.name)
+		for _, child := range r.children {
+			skip(g, child, reason)
+		}
+		g.reporter.EndDescribe()
+	case *It:
+		if r.h == nil {
+			g.reporter.ItIsPending(r.name)
+		} else if sr, ok := g.reporter.(SkipReporter); ok {
+			sr.ItSkipped(r.name, reason)
+		} else {
+			g.reporter.ItIsExcluded(r.name)
+		}
+	default:
+		r.run(g)
+	}
+}
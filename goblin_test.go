@@ -4,6 +4,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -318,6 +319,89 @@ func TestJustBeforeEach(t *testing.T) {
 	}
 }
 
+func TestJustAfterEach(t *testing.T) {
+	fakeTest := testing.T{}
+
+	g := Goblin(&fakeTest)
+	var ran []string
+
+	g.Describe("Outer", func() {
+		g.AfterEach(func() {
+			ran = append(ran, "AfterEach")
+		})
+
+		g.JustAfterEach(func() {
+			ran = append(ran, "JustAfterEach")
+		})
+
+		g.Describe("Nested", func() {
+			g.AfterEach(func() {
+				ran = append(ran, "nAfterEach")
+			})
+
+			g.JustAfterEach(func() {
+				ran = append(ran, "nJustAfterEach")
+			})
+
+			g.It("should run all just after handlers first", func() {
+				ran = append(ran, "It")
+			})
+
+			g.It("should run them asynchronously too", func(done Done) {
+				ran = append(ran, "It")
+				done()
+			})
+		})
+	})
+
+	expected := []string{"It", "nJustAfterEach", "JustAfterEach", "nAfterEach", "AfterEach"}
+	if !reflect.DeepEqual(ran, append(expected, expected...)) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+}
+
+func TestJustAfterEachOnFailure(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var mu sync.Mutex
+	var ran []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, name)
+	}
+	g.Describe("Failing", func() {
+		g.AfterEach(func() {
+			record("AfterEach")
+		})
+		g.JustAfterEach(func() {
+			record("JustAfterEach")
+		})
+
+		g.It("Should run the hooks after failing", func() {
+			g.Fail("failed")
+		}, Timeout(time.Second))
+		g.It("Should run the hooks after failing asynchronously", func(done Done) {
+			go func() {
+				g.Fail("failed")
+			}()
+		}, Timeout(time.Second))
+		g.It("Should run the hooks after timing out", func(done Done) {}, Timeout(10*time.Millisecond))
+	})
+
+	expected := []string{"JustAfterEach", "AfterEach"}
+	if !reflect.DeepEqual(ran, append(expected, append(expected, expected...)...)) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	if len(reporter.fails) != 3 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
 func TestNotRunBeforesOrAfters(t *testing.T) {
 	fakeTest := testing.T{}
 
@@ -434,6 +518,37 @@ func TestRegex(t *testing.T) {
 	runRegex = nil
 }
 
+func TestSkipRegex(t *testing.T) {
+	args := os.Args
+	defer func() {
+		os.Args = args
+	}()
+
+	fakeTest := testing.T{}
+	os.Args = append(os.Args, "-goblin.skip=flaky|slow")
+	parseFlags()
+	g := Goblin(&fakeTest)
+
+	g.Describe("Test", func() {
+		g.It("Is flaky", func() {
+			g.Fail("Regex should skip it")
+		})
+		g.It("Is slow", func() {
+			g.Fail("Regex should skip it")
+		})
+
+		g.It("Is fine", func() {})
+	})
+
+	if fakeTest.Failed() {
+		t.Fatal("Failed")
+	}
+
+	// Reset the regex so other tests can run
+	*skipParam = ""
+	skipRegex = nil
+}
+
 func TestFailImmediately(t *testing.T) {
 	fakeTest := testing.T{}
 	g := Goblin(&fakeTest)
@@ -503,6 +618,31 @@ func TestItTimeout(t *testing.T) {
 	}
 }
 
+func TestItTimeoutShorthand(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Test", func() {
+		g.ItTimeout("Should pass within its timeout", time.Second, func() {
+			time.Sleep(20 * time.Millisecond)
+		})
+
+		g.ItTimeout("Should fail past its timeout", 10*time.Millisecond, func() {
+			time.Sleep(50 * time.Millisecond)
+		}, Label("slow"))
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass within its timeout"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail past its timeout"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
 func TestIsNilAndIsNotNil(t *testing.T) {
 	fakeTest := testing.T{}
 	g := Goblin(&fakeTest)
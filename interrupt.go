@@ -0,0 +1,103 @@
+package goblin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+)
+
+// exit terminates the process once an interrupted run has been reported
+var exit = os.Exit
+
+// handleInterrupts traps SIGINT and SIGTERM until the returned function is
+// called, so that an interrupted run stops scheduling tests and still reports
+// the ones which completed instead of losing all results. SIGQUIT, or a second
+// interrupt, dumps the progress of the tests being run, which helps to find
+// where a hung suite is stuck, and a third interrupt exits at once.
+func (g *G) handleInterrupts() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	dumps := make(chan os.Signal, 1)
+	if len(progressSignals) > 0 {
+		signal.Notify(dumps, progressSignals...)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		interrupts := 0
+		for {
+			select {
+			case <-signals:
+				interrupts++
+				g.interruptedTimes(interrupts)
+			case <-dumps:
+				g.dumpProgress(progressOutput)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		signal.Stop(dumps)
+		close(done)
+	}
+}
+
+// interruptedTimes handles the nth interrupt of the run
+func (g *G) interruptedTimes(n int) {
+	switch n {
+	case 1:
+		g.interrupt()
+	case 2:
+		g.dumpProgress(progressOutput)
+		fmt.Fprintln(progressOutput, "  Interrupt again to exit immediately")
+	default:
+		fmt.Fprintln(progressOutput, "\n  Interrupted again, exiting")
+		exit(1)
+	}
+}
+
+// interrupt stops the run from scheduling any more tests. The test in flight
+// runs to completion along with the After hooks of the blocks being run.
+func (g *G) interrupt() {
+	if atomic.CompareAndSwapInt32(&g.interrupted, 0, 1) {
+		fmt.Println("\n  Interrupted, finishing the current test...")
+		close(g.interruption())
+	}
+}
+
+// interruption returns a channel which is closed once the run is interrupted
+func (g *G) interruption() chan struct{} {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.interruptions == nil {
+		g.interruptions = make(chan struct{})
+	}
+	return g.interruptions
+}
+
+// specContext returns the context of a spec taking one, which is cancelled
+// when the run is interrupted, or by calling cancel once the spec ends or
+// times out
+func (g *G) specContext() (ctx context.Context, cancel func()) {
+	ctx, cancel = context.WithCancel(context.Background())
+	interruption := g.suite().interruption()
+	go func() {
+		select {
+		case <-interruption:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// isInterrupted reports whether the run has been interrupted
+func (g *G) isInterrupted() bool {
+	return atomic.LoadInt32(&g.interrupted) == 1
+}
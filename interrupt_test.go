@@ -0,0 +1,125 @@
+package goblin
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInterrupt(t *testing.T) {
+	var ran []string
+	exitCode := 0
+	exit = func(code int) {
+		exitCode = code
+		ran = append(ran, "exit")
+	}
+	defer func() { exit = os.Exit }()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.AfterSuite(func() {
+		ran = append(ran, "AfterSuite")
+	})
+	g.Describe("Interrupted", func() {
+		g.After(func() {
+			ran = append(ran, "After")
+		})
+		g.AfterEach(func() {
+			ran = append(ran, "AfterEach")
+		})
+
+		g.It("Should finish the current test", func() {
+			g.interrupt()
+			ran = append(ran, "It")
+		})
+		g.It("Should not run", func() {
+			ran = append(ran, "Not run")
+		})
+
+		g.Describe("Nested", func() {
+			g.Before(func() {
+				ran = append(ran, "Nested Before")
+			})
+			g.It("Should not run either", func() {
+				ran = append(ran, "Not run")
+			})
+		})
+	})
+
+	g.Describe("After the interruption", func() {
+		g.It("Should not run", func() {
+			ran = append(ran, "Not run")
+		})
+	})
+
+	// Exiting skips the cleanups, so the suite is torn down before
+	if !reflect.DeepEqual(ran, []string{"It", "AfterEach", "After", "AfterSuite", "exit"}) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should finish the current test"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reporter.endFlag {
+		t.Fatal("Failed: didn't report the summary")
+	}
+	if !fakeTest.Failed() || exitCode != 1 {
+		t.Fatalf("Failed: failed %v, exit code %d", fakeTest.Failed(), exitCode)
+	}
+}
+
+func TestInterruptCancelsContext(t *testing.T) {
+	exit = func(code int) {}
+	defer func() { exit = os.Exit }()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	var err error
+	g.Describe("Interrupted", func() {
+		g.It("Should stop once interrupted", func(ctx context.Context) {
+			g.interrupt()
+			<-ctx.Done()
+			err = ctx.Err()
+		}, Timeout(time.Second))
+	})
+
+	if err != context.Canceled {
+		t.Fatalf("Failed: context error %v", err)
+	}
+}
+
+func TestInterruptedTimes(t *testing.T) {
+	exitCode := 0
+	exit = func(code int) { exitCode = code }
+	output := &bytes.Buffer{}
+	progressOutput = output
+	defer func() {
+		exit = os.Exit
+		progressOutput = os.Stdout
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+
+	g.interruptedTimes(1)
+	if !g.isInterrupted() || output.Len() != 0 {
+		t.Fatalf("Failed: interrupted %v, output %q", g.isInterrupted(), output.String())
+	}
+	g.interruptedTimes(2)
+	if !strings.Contains(output.String(), "No test is running") || exitCode != 0 {
+		t.Fatalf("Failed: output %q, exit code %d", output.String(), exitCode)
+	}
+	g.interruptedTimes(3)
+	if exitCode != 1 {
+		t.Fatalf("Failed: exit code %d", exitCode)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package goblin
+
+import (
+	"os"
+	"syscall"
+)
+
+// progressSignals request a dump of the test in progress
+var progressSignals = []os.Signal{syscall.SIGQUIT}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package goblin
+
+import (
+	"os"
+)
+
+// progressSignals request a dump of the test in progress. Windows has no
+// SIGQUIT, so a second interrupt is the only way to request one.
+var progressSignals []os.Signal
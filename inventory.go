@@ -0,0 +1,129 @@
+package goblin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// InventoryEntry describes a declared spec for discovery tools.
+type InventoryEntry struct {
+	ID       string   `json:"id"`
+	Path     []string `json:"path"` // Names of the enclosing Describe blocks and of the spec
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Labels   []string `json:"labels,omitempty"`
+	Owner    string   `json:"owner,omitempty"`
+	Links    []string `json:"links,omitempty"`
+	Pending  bool     `json:"pending"`
+	Excluded bool     `json:"excluded"`
+}
+
+// sourceLocation is where a spec was declared
+type sourceLocation struct {
+	file string
+	line int
+}
+
+func (l sourceLocation) String() string {
+	return fmt.Sprintf("%s:%d", l.file, l.line)
+}
+
+// goblinDir is the directory of the goblin package, whose frames are skipped
+// when resolving where a spec was declared
+var goblinDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerLocation returns the location of the first caller outside of goblin
+func callerLocation() sourceLocation {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != goblinDir || strings.HasSuffix(frame.File, "_test.go") {
+			return sourceLocation{file: frame.File, line: frame.Line}
+		}
+		if !more {
+			return sourceLocation{}
+		}
+	}
+}
+
+// specID creates an identifier for the spec at path declared in file which
+// doesn't change when sibling specs are renamed or reordered
+func specID(path []string, file string) string {
+	sum := sha256.Sum256([]byte(strings.Join(path, "\x00") + "\x00" + filepath.Base(file)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// id returns the stable identifier of the spec
+func (it *It) id() string {
+	return specID(append(it.parent.path(), it.name), it.location.file)
+}
+
+// path returns the names of the block and its parents, outermost first
+func (d *Describe) path() []string {
+	if d == nil {
+		return nil
+	}
+	return append(d.parent.path(), d.name)
+}
+
+// inventory appends an entry for every spec of the block to entries
+func (d *Describe) inventory(entries []InventoryEntry) []InventoryEntry {
+	for _, r := range d.children {
+		var entry InventoryEntry
+		switch child := r.(type) {
+		case *Describe:
+			entries = child.inventory(entries)
+			continue
+		case *It:
+			entry = InventoryEntry{
+				Path:    append(d.path(), child.name),
+				File:    child.location.file,
+				Line:    child.location.line,
+				Labels:  child.labels,
+				Owner:   child.owner,
+				Links:   child.links,
+				Pending: child.h == nil,
+			}
+		case *Xit:
+			entry = InventoryEntry{
+				Path:     append(d.path(), child.name),
+				File:     child.location.file,
+				Line:     child.location.line,
+				Excluded: true,
+			}
+		}
+		entry.ID = specID(entry.Path, entry.File)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+var (
+	inventoryEntries []InventoryEntry
+	inventoryMu      sync.Mutex
+)
+
+// writeInventory adds the specs of a top level block to the inventory and
+// writes it to path
+func writeInventory(path string, d *Describe) error {
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+	inventoryEntries = d.inventory(inventoryEntries)
+
+	data, err := json.MarshalIndent(inventoryEntries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,132 @@
+package goblin
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestInventory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	*inventoryFile = path
+	defer func() {
+		*inventoryFile = ""
+		inventoryEntries = nil
+	}()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	ran := false
+	g.Describe("Inventory", func() {
+		g.Before(func() { ran = true })
+		g.Describe("Nested", func() {
+			g.It("Should be listed", func() { ran = true }, Label("fast"))
+		})
+		g.It("Should be pending")
+		g.Xit("Should be excluded", func() {})
+	})
+
+	if ran || reporter.beginFlag {
+		t.Fatal("Failed: specs should not run")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []InventoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Failed: entries %+v", entries)
+	}
+
+	listed := entries[0]
+	if !reflect.DeepEqual(listed.Path, []string{"Inventory", "Nested", "Should be listed"}) ||
+		!reflect.DeepEqual(listed.Labels, []string{"fast"}) || listed.Pending || listed.Excluded {
+		t.Fatalf("Failed: entry %+v", listed)
+	}
+	if filepath.Base(listed.File) != "inventory_test.go" || listed.Line == 0 {
+		t.Fatalf("Failed: location %s:%d", listed.File, listed.Line)
+	}
+	if listed.ID != specID(listed.Path, listed.File) || listed.ID == entries[1].ID {
+		t.Fatalf("Failed: ID %s", listed.ID)
+	}
+	if !entries[1].Pending || !entries[2].Excluded {
+		t.Fatalf("Failed: status %+v", entries[1:])
+	}
+}
+
+func TestStableIDs(t *testing.T) {
+	ids := func(first, second string) map[string]string {
+		fakeTest := testing.T{}
+		reporter := outputReporter{}
+		g := Goblin(&fakeTest)
+		g.SetReporter(Reporter(&reporter))
+
+		g.Describe("IDs", func() {
+			g.It(first, func() { g.Fail("failed") })
+			g.It(second, func() { g.Fail("failed") })
+		})
+
+		ids := map[string]string{}
+		for _, failure := range reporter.captured {
+			ids[failure.TestName] = failure.ID
+		}
+		return ids
+	}
+
+	before := ids("Should be stable", "Should be renamed")
+	after := ids("Should have been renamed", "Should be stable")
+	if before["IDs Should be stable"] == "" || before["IDs Should be stable"] != after["IDs Should be stable"] {
+		t.Fatalf("Failed: %v, %v", before, after)
+	}
+	if before["IDs Should be stable"] == before["IDs Should be renamed"] {
+		t.Fatalf("Failed: IDs collide %v", before)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	output := &bytes.Buffer{}
+	dryRunOutput = output
+	*dryRun = true
+	defer func() {
+		dryRunOutput = os.Stdout
+		*dryRun = false
+	}()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	ran := false
+	g.Describe("Numbers", func() {
+		g.Before(func() {
+			ran = true
+		})
+		g.It("Should add", func() {
+			ran = true
+		})
+		g.It("Should subtract")
+		g.Describe("Nested", func() {
+			g.Xit("Should divide", func() {})
+		})
+	})
+
+	if ran || reporter.beginFlag {
+		t.Fatal("Failed: ran the suite")
+	}
+	expected := "Numbers\n  Should add\n  - Should subtract (pending)\n  Nested\n    - Should divide (excluded)\n"
+	if output.String() != expected {
+		t.Fatalf("Failed: listed %q", output.String())
+	}
+}
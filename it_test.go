@@ -1,7 +1,13 @@
 package goblin
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestBeforeEach(t *testing.T) {
@@ -172,3 +178,163 @@ func TestNestedAfterEach(t *testing.T) {
 		t.Fatal("Failed")
 	}
 }
+
+func TestBeforeEachError(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	count := 0
+	g.Describe("Numbers", func() {
+		g.BeforeEach(func() error {
+			count++
+			if count == 1 {
+				return errors.New("not ready")
+			}
+			return nil
+		})
+
+		g.It("Should fail because of the hook", func() {})
+		g.It("Should pass", func() {})
+	})
+
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail because of the hook"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+}
+
+func TestAfterEachError(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Numbers", func() {
+		g.AfterEach(func() error {
+			return errors.New("leaked connection")
+		})
+
+		g.It("Should fail because of the hook", func() {})
+	})
+
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail because of the hook"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestInvalidHook(t *testing.T) {
+	g := Goblin(&testing.T{})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Failed: didn't panic")
+		}
+	}()
+	g.Describe("Numbers", func() {
+		g.BeforeEach(func(i int) {})
+	})
+}
+
+func TestInvalidHandler(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := tableFileReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Numbers", func() {
+		g.It("Should not run", func(i int) {})
+		g.Xit("Should not be excluded", "not a func")
+		g.It("Should still run", func() {})
+	})
+
+	if !reflect.DeepEqual(reporter.fails, []string{"Should not run", "Should not be excluded"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should still run"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	for _, stack := range reporter.failureStacks {
+		if len(stack) != 1 || !strings.Contains(stack[0], "it_test.go:") {
+			t.Fatalf("Failed: stack %v", stack)
+		}
+	}
+}
+
+func TestConcurrentAssertions(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Concurrency", func() {
+		g.It("Should keep every failure", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					g.Assert(i).Equal(-1)
+				}(i)
+			}
+			wg.Wait()
+		}, Timeout(time.Second))
+		g.It("Should pass afterwards", func() {})
+		g.It("Should fail once when done", func(done Done) {
+			for i := 0; i < 10; i++ {
+				go g.Fail("failed")
+			}
+		}, Timeout(time.Second))
+	})
+
+	if !reflect.DeepEqual(reporter.fails, []string{"Should keep every failure", "Should fail once when done"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass afterwards"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if failure := reporter.captured[0]; len(failure.Additional) != 9 {
+		t.Fatalf("Failed: %d additional failures", len(failure.Additional))
+	}
+}
+
+func TestContextHandler(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	stopped := make(chan error, 1)
+	g.Describe("Context", func() {
+		g.It("Should pass a live context", func(ctx context.Context) {
+			g.Assert(ctx.Err()).IsNil()
+		})
+		g.It("Should cancel the context on timeout", func(ctx context.Context) {
+			<-ctx.Done()
+			stopped <- ctx.Err()
+		}, Timeout(10*time.Millisecond))
+	})
+
+	select {
+	case err := <-stopped:
+		if err != context.Canceled {
+			t.Fatalf("Failed: context error %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Failed: context wasn't cancelled")
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass a live context"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should cancel the context on timeout"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
@@ -0,0 +1,140 @@
+package goblin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// jsonEvent is a line written by the JSONReporter
+type jsonEvent struct {
+	Time    time.Time `json:"time"`
+	Event   string    `json:"event"`
+	Name    string    `json:"name,omitempty"`
+	Path    []string  `json:"path,omitempty"` // Names of the enclosing Describe blocks
+	Elapsed *float64  `json:"elapsed,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+	Attempt int       `json:"attempt,omitempty"`
+	ID      string    `json:"id,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Stack   []string  `json:"stack,omitempty"`
+	File    string    `json:"file,omitempty"` // Where the failing assertion was made
+	Line    int       `json:"line,omitempty"`
+	Func    string    `json:"func,omitempty"`
+	Output  string    `json:"output,omitempty"`
+	Seed    int64     `json:"seed,omitempty"`
+	Stats   *Stats    `json:"stats,omitempty"`
+}
+
+// JSONReporter writes one JSON object per line for each event of the run, so
+// tools can follow it without parsing the terminal output. Every event has an
+// "event" field, one of begin, end, describe_begin, describe_end, passed,
+// failed, pending, excluded, skipped, failed_as_expected, attempt_failed,
+// failure, measured and randomized, along with the fields relevant to it, such
+// as the "name" and "path" of the spec and its "elapsed" seconds. Selected with
+// -goblin.format=json.
+type JSONReporter struct {
+	w         io.Writer
+	describes []string
+	took      *float64 // Duration of the spec being reported
+	mu        sync.Mutex
+}
+
+// NewJSONReporter creates a JSONReporter writing to w.
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{w: w}
+}
+
+func (r *JSONReporter) emit(e jsonEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.Time = time.Now()
+	data, _ := json.Marshal(e)
+	fmt.Fprintf(r.w, "%s\n", data)
+}
+
+// spec emits an event about the spec name, in the Describe block being
+// reported
+func (r *JSONReporter) spec(event, name string, e jsonEvent) {
+	e.Event, e.Name = event, name
+	e.Path = append([]string(nil), r.describes...)
+	r.emit(e)
+}
+
+// elapsed returns the duration of the spec being reported, if known
+func (r *JSONReporter) elapsed() *float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	took := r.took
+	r.took = nil
+	return took
+}
+
+func (r *JSONReporter) BeginDescribe(name string) {
+	r.emit(jsonEvent{Event: "describe_begin", Name: name, Path: append([]string(nil), r.describes...)})
+	r.describes = append(r.describes, name)
+}
+
+func (r *JSONReporter) EndDescribe() {
+	name := r.describes[len(r.describes)-1]
+	r.describes = r.describes[:len(r.describes)-1]
+	r.emit(jsonEvent{Event: "describe_end", Name: name, Path: append([]string(nil), r.describes...)})
+}
+
+func (r *JSONReporter) Begin() {
+	r.emit(jsonEvent{Event: "begin"})
+}
+
+func (r *JSONReporter) ItMeasured(name string, stats *Stats) {
+	r.spec("measured", name, jsonEvent{Stats: stats})
+}
+
+func (r *JSONReporter) Randomized(seed int64) {
+	r.emit(jsonEvent{Event: "randomized", Seed: seed})
+}
+
+func (r *JSONReporter) End() {
+	r.emit(jsonEvent{Event: "end"})
+}
+
+func (r *JSONReporter) Failure(failure *Failure) {
+	r.emit(jsonEvent{Event: "failure", Name: failure.TestName, ID: failure.ID, Message: failure.Message, Stack: failure.Stack,
+		File: failure.File, Line: failure.Line, Func: failure.FuncName, Output: failure.Output})
+}
+
+func (r *JSONReporter) ItTook(duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	seconds := duration.Seconds()
+	r.took = &seconds
+}
+
+func (r *JSONReporter) ItFailed(name string) {
+	r.spec("failed", name, jsonEvent{Elapsed: r.elapsed()})
+}
+
+func (r *JSONReporter) ItPassed(name string) {
+	r.spec("passed", name, jsonEvent{Elapsed: r.elapsed()})
+}
+
+func (r *JSONReporter) ItIsPending(name string) {
+	r.spec("pending", name, jsonEvent{})
+}
+
+func (r *JSONReporter) ItIsExcluded(name string) {
+	r.spec("excluded", name, jsonEvent{})
+}
+
+func (r *JSONReporter) ItSkipped(name, reason string) {
+	r.spec("skipped", name, jsonEvent{Reason: reason})
+}
+
+func (r *JSONReporter) ItFailedAsExpected(name, reason string) {
+	r.spec("failed_as_expected", name, jsonEvent{Reason: reason, Elapsed: r.elapsed()})
+}
+
+func (r *JSONReporter) ItAttemptFailed(name string, attempt int, failure *Failure, delay time.Duration) {
+	r.spec("attempt_failed", name, jsonEvent{Attempt: attempt, Message: failure.Message, Stack: failure.Stack, Elapsed: r.elapsed()})
+}
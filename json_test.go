@@ -0,0 +1,61 @@
+package goblin
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONReporter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewJSONReporter(&out)))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.Describe("Nested", func() {
+			g.It("Should fail", func() {
+				g.Fail("failed")
+			}, Timeout(time.Second))
+		})
+		g.It("Should be pending")
+	})
+
+	var events []jsonEvent
+	var summary []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var e jsonEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+		summary = append(summary, e.Event+" "+strings.Join(append(e.Path, e.Name), "/"))
+	}
+
+	expected := []string{
+		"begin ",
+		"describe_begin Numbers",
+		"passed Numbers/Should add",
+		"describe_begin Numbers/Nested",
+		"failed Numbers/Nested/Should fail",
+		"failure Nested Should fail",
+		"describe_end Numbers/Nested",
+		"pending Numbers/Should be pending",
+		"describe_end Numbers",
+		"end ",
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Fatalf("Failed: events\n%s", strings.Join(summary, "\n"))
+	}
+	if events[2].Elapsed == nil || events[7].Elapsed != nil {
+		t.Fatalf("Failed: elapsed %v, %v", events[2].Elapsed, events[7].Elapsed)
+	}
+	if failure := events[5]; failure.Message != "failed" || failure.ID == "" || len(failure.Stack) == 0 {
+		t.Fatalf("Failed: failure %+v", failure)
+	}
+}
@@ -0,0 +1,81 @@
+package goblin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EqlJSON asserts that the source and expected, JSON documents given as a
+// []byte, json.RawMessage or string, are equal once parsed: the order of keys
+// and whitespace don't matter, and numbers are compared by value, e.g.
+//
+//	g.Assert(recorder.Body.String()).EqlJSON(`{"id": 42, "tags": ["a"]}`)
+//
+// The displayed message shows a diff of the documents, indented with sorted
+// keys, if the assertion fails.
+func (a *Assertion) EqlJSON(expected string, messages ...interface{}) {
+	actual, err := decodeJSON(a.src)
+	if err != nil {
+		a.fail(fmt.Sprintf("%v%s", err, formatMessages(messages...)))
+		return
+	}
+	want, err := decodeJSON(expected)
+	if err != nil {
+		a.fail(fmt.Sprintf("expected %v%s", err, formatMessages(messages...)))
+		return
+	}
+
+	actual, want = normalizeJSONNumbers(actual), normalizeJSONNumbers(want)
+	if jsonEqual(actual, want) {
+		return
+	}
+	diff, _ := unifiedDiff(indentJSON(want), indentJSON(actual))
+	a.fail(fmt.Sprintf("JSON does not equal expected%s\n%s", formatMessages(messages...), diff))
+}
+
+// jsonEqual compares decoded JSON values, with normalized numbers
+func jsonEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			if w, ok := b[k]; !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case int:
+		if f, ok := b.(float64); ok {
+			return float64(a) == f
+		}
+	case float64:
+		if i, ok := b.(int); ok {
+			return a == float64(i)
+		}
+	}
+	return a == b
+}
+
+// indentJSON renders a decoded JSON value as indented lines
+func indentJSON(value interface{}) []string {
+	data, err := json.MarshalIndent(value, "", "    ")
+	if err != nil {
+		return []string{fmt.Sprintf("%#v", value)}
+	}
+	return strings.Split(string(data), "\n")
+}
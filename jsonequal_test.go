@@ -0,0 +1,46 @@
+package goblin
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEqlJSON(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: []byte(`{"b": [1, 2.5], "a": {"c": null}}`), fail: verifier.FailFunc}
+	a.EqlJSON(`{"a": {"c": null}, "b": [1.0, 2.5]}`)
+	a = Assertion{src: json.RawMessage(`"x"`), fail: verifier.FailFunc}
+	a.EqlJSON(` "x" `)
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: `{"id": 42, "tags": ["a", "b"], "ok": true}`, fail: verifier.FailFunc}
+	a.EqlJSON(`{"id": 42, "tags": ["a"], "ok": true}`, "body")
+	verifier.VerifyMessage(t, "JSON does not equal expected, body\n"+
+		"--- expected\n"+
+		"+++ actual\n"+
+		" {\n"+
+		`     "id": 42,`+"\n"+
+		`     "ok": true,`+"\n"+
+		`     "tags": [`+"\n"+
+		`-        "a"`+"\n"+
+		`+        "a",`+"\n"+
+		`+        "b"`+"\n"+
+		"     ]\n"+
+		" }")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: `{`, fail: verifier.FailFunc}
+	a.EqlJSON(`{}`)
+	verifier.VerifyMessage(t, "invalid JSON: unexpected EOF")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: `{}`, fail: verifier.FailFunc}
+	a.EqlJSON(`[`)
+	verifier.VerifyMessage(t, "expected invalid JSON: unexpected EOF")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: 42, fail: verifier.FailFunc}
+	a.EqlJSON(`42`)
+	verifier.VerifyMessage(t, "42 is not a JSON document")
+}
@@ -0,0 +1,188 @@
+package goblin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPath evaluates a JSONPath expression against the source, a JSON
+// document given as a []byte, json.RawMessage or string, and returns an
+// Assertion about the extracted value, e.g.
+//
+//	g.Assert(body).JSONPath("$.items[0].id").Equal(42)
+//
+// Expressions start with $ followed by any number of .key, ['key'] or [index]
+// selectors; negative indexes count from the end of arrays. Numbers are
+// extracted as int when they are integers, and as float64 otherwise. Failures
+// of the returned Assertion are prefixed with the path.
+func (a *Assertion) JSONPath(path string, messages ...interface{}) *Assertion {
+	fail := func(msg interface{}) {
+		a.fail(fmt.Sprintf("%s: %v", path, msg))
+	}
+	// Once extracting the value failed, assertions about it are moot
+	failed := func(msg string) *Assertion {
+		fail(msg + formatMessages(messages...))
+		return &Assertion{fail: func(interface{}) {}}
+	}
+
+	value, err := decodeJSON(a.src)
+	if err != nil {
+		return failed(err.Error())
+	}
+
+	selectors, err := parseJSONPath(path)
+	if err != nil {
+		return failed(err.Error())
+	}
+	for _, s := range selectors {
+		if value, err = s.selectFrom(value); err != nil {
+			return failed(err.Error())
+		}
+	}
+
+	return &Assertion{src: normalizeJSONNumbers(value), fail: fail, comparators: a.comparators}
+}
+
+// decodeJSON decodes doc, a JSON document given as a []byte, json.RawMessage
+// or string, keeping its numbers as json.Number
+func decodeJSON(doc interface{}) (interface{}, error) {
+	var data []byte
+	switch doc := doc.(type) {
+	case []byte:
+		data = doc
+	case json.RawMessage:
+		data = doc
+	case string:
+		data = []byte(doc)
+	default:
+		return nil, fmt.Errorf("%#v is not a JSON document", doc)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	return value, nil
+}
+
+// jsonSelector selects either a key of an object or an index of an array
+type jsonSelector struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (s jsonSelector) selectFrom(value interface{}) (interface{}, error) {
+	if s.isIndex {
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot index %s with [%d]", jsonKind(value), s.index)
+		}
+		i := s.index
+		if i < 0 {
+			i += len(array)
+		}
+		if i < 0 || i >= len(array) {
+			return nil, fmt.Errorf("index [%d] out of range of array of length %d", s.index, len(array))
+		}
+		return array[i], nil
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot select %q from %s", s.key, jsonKind(value))
+	}
+	v, ok := object[s.key]
+	if !ok {
+		return nil, fmt.Errorf("no key %q", s.key)
+	}
+	return v, nil
+}
+
+// parseJSONPath parses the selectors of path
+func parseJSONPath(path string) ([]jsonSelector, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid JSONPath %q, expected it to start with $", path)
+	}
+	rest := path[1:]
+
+	var selectors []jsonSelector
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("invalid JSONPath %q, empty key", path)
+			}
+			selectors = append(selectors, jsonSelector{key: key})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSONPath %q, unclosed [", path)
+			}
+			inner := rest[1:end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				selectors = append(selectors, jsonSelector{key: inner[1 : len(inner)-1]})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid JSONPath %q, bad index [%s]", path, inner)
+				}
+				selectors = append(selectors, jsonSelector{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSONPath %q, unexpected %q", path, rest[0])
+		}
+	}
+	return selectors, nil
+}
+
+// jsonKind describes the kind of a decoded JSON value
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	}
+	return "null"
+}
+
+// normalizeJSONNumbers converts the numbers of a decoded JSON value to int
+// when they are integers, and to float64 otherwise
+func normalizeJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := strconv.Atoi(v.String()); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeJSONNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = normalizeJSONNumbers(v[k])
+		}
+	}
+	return value
+}
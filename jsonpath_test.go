@@ -0,0 +1,41 @@
+package goblin
+
+import (
+	"testing"
+)
+
+const jsonDoc = `{"items": [{"id": 42, "price": 9.5, "tags": ["a", "b"]}], "next": null, "a.b": true}`
+
+func TestJSONPath(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: []byte(jsonDoc), fail: verifier.FailFunc}
+	a.JSONPath("$.items[0].id").Equal(42)
+	a.JSONPath("$.items[0].price").Equal(9.5)
+	a.JSONPath("$.items[0].tags[-1]").Equal("b")
+	a.JSONPath("$.items[0]['tags']").Equal([]interface{}{"a", "b"})
+	a.JSONPath("$['a.b']").IsTrue()
+	a.JSONPath("$.next").IsNil()
+	verifier.Verify(t)
+}
+
+func TestJSONPathFailures(t *testing.T) {
+	for path, message := range map[string]string{
+		"$.items[0].id":    "$.items[0].id: 42 does not equal 43",
+		"$.items[1]":       "$.items[1]: index [1] out of range of array of length 1",
+		"$.missing":        `$.missing: no key "missing"`,
+		"$.items.id":       `$.items.id: cannot select "id" from an array`,
+		"items":            `items: invalid JSONPath "items", expected it to start with $`,
+		"$.items[first]":   `$.items[first]: invalid JSONPath "$.items[first]", bad index [first]`,
+		"$.items[0].tags[": `$.items[0].tags[: invalid JSONPath "$.items[0].tags[", unclosed [`,
+	} {
+		verifier := AssertionVerifier{ShouldPass: false}
+		a := Assertion{src: jsonDoc, fail: verifier.FailFunc}
+		a.JSONPath(path).Equal(43)
+		verifier.VerifyMessage(t, message)
+	}
+
+	verifier := AssertionVerifier{ShouldPass: false}
+	a := Assertion{src: "{", fail: verifier.FailFunc}
+	a.JSONPath("$")
+	verifier.VerifyMessage(t, "$: invalid JSON: unexpected EOF")
+}
@@ -0,0 +1,183 @@
+package goblin
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// junitMu guards junitSuites, which accumulates the suites of every Go test of
+// the package so they all end up in the -goblin.junit file
+var junitMu sync.Mutex
+var junitSuites []*junitTestSuite
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     float64           `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      float64          `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	Cases     []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	File      string        `xml:"file,attr,omitempty"` // Of the failing assertion
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// JUnitReporter writes the results of a suite as JUnit XML, as consumed by the
+// test result views of CI systems like Jenkins and GitLab. Each Go test
+// running a suite becomes a testsuite element, with a testcase element for
+// each spec, and the file is rewritten as each suite ends. Enabled with
+// -goblin.junit, alongside the usual output.
+type JUnitReporter struct {
+	path      string
+	suite     *junitTestSuite
+	describes []string
+	took      time.Duration
+	tookMu    sync.Mutex
+	start     time.Time
+	failing   *junitTestCase // Case which failed, awaiting its failure
+	appended  bool           // Whether suite was added to junitSuites
+}
+
+// NewJUnitReporter creates a JUnitReporter writing to the file at path, for
+// the suite run by the Go test named test.
+func NewJUnitReporter(path, test string) *JUnitReporter {
+	return &JUnitReporter{path: path, suite: &junitTestSuite{Name: test}}
+}
+
+// addCase adds a case for the spec name, in the Describe block being reported
+func (r *JUnitReporter) addCase(name string) *junitTestCase {
+	r.tookMu.Lock()
+	took := r.took
+	r.took = 0
+	r.tookMu.Unlock()
+
+	c := &junitTestCase{Name: name, ClassName: strings.Join(r.describes, " "), Time: took.Seconds()}
+	r.suite.Cases = append(r.suite.Cases, c)
+	r.suite.Tests++
+	r.suite.Time += c.Time
+	return c
+}
+
+// skip adds a case for a spec which didn't run
+func (r *JUnitReporter) skip(name, reason string) {
+	c := r.addCase(name)
+	c.Skipped = &junitSkipped{Message: reason}
+	r.suite.Skipped++
+}
+
+func (r *JUnitReporter) BeginDescribe(name string) {
+	r.describes = append(r.describes, name)
+}
+
+func (r *JUnitReporter) EndDescribe() {
+	r.describes = r.describes[:len(r.describes)-1]
+}
+
+func (r *JUnitReporter) Begin() {
+	r.start = time.Now()
+	r.suite.Timestamp = r.start.UTC().Format("2006-01-02T15:04:05")
+}
+
+func (r *JUnitReporter) End() {
+	if err := r.write(); err != nil {
+		fmt.Printf("goblin: could not write JUnit report: %v\n", err)
+	}
+}
+
+func (r *JUnitReporter) ItTook(duration time.Duration) {
+	r.tookMu.Lock()
+	defer r.tookMu.Unlock()
+	r.took = duration
+}
+
+func (r *JUnitReporter) ItFailed(name string) {
+	r.failing = r.addCase(name)
+	r.suite.Failures++
+}
+
+func (r *JUnitReporter) Failure(failure *Failure) {
+	if r.failing == nil {
+		return
+	}
+	lines := append([]string{failure.Message}, failure.Stack...)
+	for _, additional := range failure.Additional {
+		lines = append(append(lines, "Also: "+additional.Message), additional.Stack...)
+	}
+	r.failing.Failure = &junitFailure{Message: failure.Message, Type: "failure", Text: strings.Join(lines, "\n")}
+	r.failing.SystemOut = failure.Output
+	r.failing.File, r.failing.Line = failure.File, failure.Line
+	r.failing = nil
+}
+
+func (r *JUnitReporter) ItPassed(name string) {
+	r.addCase(name)
+}
+
+func (r *JUnitReporter) ItIsPending(name string) {
+	r.skip(name, "pending")
+}
+
+func (r *JUnitReporter) ItIsExcluded(name string) {
+	r.skip(name, "excluded")
+}
+
+func (r *JUnitReporter) ItSkipped(name, reason string) {
+	r.skip(name, reason)
+}
+
+// write adds the suite to those of the other Go tests of the package, once
+// even if several top-level blocks end, then rewrites the file with all of
+// them.
+func (r *JUnitReporter) write() error {
+	junitMu.Lock()
+	defer junitMu.Unlock()
+	if !r.appended {
+		junitSuites = append(junitSuites, r.suite)
+		r.appended = true
+	}
+
+	suites := junitTestSuites{Suites: junitSuites}
+	for _, suite := range junitSuites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Time += suite.Time
+	}
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append([]byte(xml.Header), append(data, '\n')...), 0644)
+}
@@ -0,0 +1,124 @@
+package goblin
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	*junitFile = path
+	defer func() {
+		*junitFile = ""
+		junitSuites = nil
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	if m, ok := g.reporter.(multiReporter); !ok || len(m) != 2 {
+		t.Fatalf("Failed: reporter %T", g.reporter)
+	}
+	// The report is still written with another reporter
+	reporter := FakeReporter{}
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.Describe("Nested", func() {
+			g.It("Should fail", func() {
+				g.Fail("failed")
+			}, Timeout(time.Second))
+			g.It("Should be skipped", func() {})
+		}, ContinueOnFailure(false))
+		g.It("Should be pending")
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if suites.Tests != 4 || suites.Failures != 1 || suites.Skipped != 2 || len(suites.Suites) != 1 {
+		t.Fatalf("Failed: suites %+v", suites)
+	}
+
+	var cases []string
+	for _, c := range suites.Suites[0].Cases {
+		cases = append(cases, c.ClassName+": "+c.Name)
+	}
+	expected := []string{"Numbers: Should add", "Numbers Nested: Should fail", "Numbers Nested: Should be skipped", "Numbers: Should be pending"}
+	if !reflect.DeepEqual(cases, expected) {
+		t.Fatalf("Failed: cases %v", cases)
+	}
+	if failure := suites.Suites[0].Cases[1].Failure; failure == nil || failure.Message != "failed" {
+		t.Fatalf("Failed: failure %+v", failure)
+	}
+	if !reflect.DeepEqual(reporter.excluded, []string{"Should be skipped"}) {
+		t.Fatalf("Failed: excluded %v", reporter.excluded)
+	}
+}
+
+func TestJUnitReporterSeveralDescribes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	defer func() {
+		junitSuites = nil
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(NewJUnitReporter(path, "TestSuite"))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+	})
+	g.Describe("Strings", func() {
+		g.It("Should concatenate", func() {}, Timeout(time.Second))
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if suites.Tests != 2 || len(suites.Suites) != 1 || len(suites.Suites[0].Cases) != 2 {
+		t.Fatalf("Failed: suites %+v", suites)
+	}
+}
+
+func TestJUnitReporterWithReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	*junitFile = path
+	defer func() {
+		*junitFile = ""
+		junitSuites = nil
+	}()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+	g := Goblin(&fakeTest, WithReporter(Reporter(&reporter)))
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	var suites junitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if suites.Tests != 1 || len(reporter.passes) != 1 {
+		t.Fatalf("Failed: suites %+v, passes %v", suites, reporter.passes)
+	}
+}
@@ -0,0 +1,175 @@
+package goblin
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// labelFilter tells whether a spec with the given labels should run
+type labelFilter func(labels map[string]bool) bool
+
+// labelFilterExpr is the filter set with -goblin.label-filter
+var labelFilterExpr labelFilter
+
+// matchesLabels tells whether a spec with labels passes the label filter
+func matchesLabels(labels []string) bool {
+	if labelFilterExpr == nil {
+		return true
+	}
+	set := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		set[l] = true
+	}
+	return labelFilterExpr(set)
+}
+
+// parseLabelFilter parses a label filter expression, e.g.
+// "integration && !requires-gpu" or "any(slow, network) || smoke". Labels are
+// combined with && (and), || (or), ! (not) and parentheses, while any(...)
+// and all(...) test a set of labels.
+func parseLabelFilter(expr string) (labelFilter, error) {
+	p := &labelFilterParser{expr: expr}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.expr) {
+		return nil, p.errorf("unexpected %q", p.expr[p.pos:])
+	}
+	return filter, nil
+}
+
+// labelFilterParser is a recursive descent parser of label filters
+type labelFilterParser struct {
+	expr string
+	pos  int
+}
+
+func (p *labelFilterParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid label filter %q at %d: %s", p.expr, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *labelFilterParser) skipSpace() {
+	for p.pos < len(p.expr) && p.expr[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// consume skips token if it's next, returning whether it was
+func (p *labelFilterParser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.expr[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *labelFilterParser) parseOr() (labelFilter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(labels map[string]bool) bool { return l(labels) || right(labels) }
+	}
+	return left, nil
+}
+
+func (p *labelFilterParser) parseAnd() (labelFilter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(labels map[string]bool) bool { return l(labels) && right(labels) }
+	}
+	return left, nil
+}
+
+func (p *labelFilterParser) parseUnary() (labelFilter, error) {
+	if p.consume("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(labels map[string]bool) bool { return !operand(labels) }, nil
+	}
+
+	if p.consume("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("expected )")
+		}
+		return inner, nil
+	}
+
+	label, err := p.parseLabel()
+	if err != nil {
+		return nil, err
+	}
+	if (label == "any" || label == "all") && p.consume("(") {
+		return p.parseSet(label == "all")
+	}
+	return func(labels map[string]bool) bool { return labels[label] }, nil
+}
+
+// parseSet parses the labels of an any(...) or all(...) test, after its (
+func (p *labelFilterParser) parseSet(all bool) (labelFilter, error) {
+	var set []string
+	for {
+		label, err := p.parseLabel()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, label)
+		if p.consume(")") {
+			break
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected , or )")
+		}
+	}
+
+	return func(labels map[string]bool) bool {
+		for _, l := range set {
+			if labels[l] != all {
+				return !all
+			}
+		}
+		return all
+	}, nil
+}
+
+func (p *labelFilterParser) parseLabel() (string, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.expr) {
+		r := rune(p.expr[p.pos])
+		if !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_.:/", r)) {
+			break
+		}
+		p.pos++
+	}
+	if p.pos == start {
+		if p.pos == len(p.expr) {
+			return "", p.errorf("expected a label")
+		}
+		return "", p.errorf("expected a label, got %q", p.expr[p.pos])
+	}
+	return p.expr[start:p.pos], nil
+}
@@ -0,0 +1,86 @@
+package goblin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLabelFilter(t *testing.T) {
+	cases := []struct {
+		expr   string
+		labels []string
+		match  bool
+	}{
+		{"integration", []string{"integration"}, true},
+		{"integration", []string{"unit"}, false},
+		{"integration && !requires-gpu", []string{"integration"}, true},
+		{"integration && !requires-gpu", []string{"integration", "requires-gpu"}, false},
+		{"unit || integration", []string{"integration"}, true},
+		{"!(unit || integration)", []string{"integration"}, false},
+		{"a || b && c", []string{"a"}, true},
+		{"(a || b) && c", []string{"a"}, false},
+		{"any(slow, network)", []string{"network"}, true},
+		{"any(slow, network)", []string{"fast"}, false},
+		{"all(slow, network)", []string{"network"}, false},
+		{"all(slow,network) && !flaky", []string{"network", "slow"}, true},
+		{"any", []string{"any"}, true},
+	}
+	for _, c := range cases {
+		filter, err := parseLabelFilter(c.expr)
+		if err != nil {
+			t.Fatalf("Failed: %v", err)
+		}
+		set := map[string]bool{}
+		for _, l := range c.labels {
+			set[l] = true
+		}
+		if filter(set) != c.match {
+			t.Fatalf("Failed: %q with %v should be %v", c.expr, c.labels, c.match)
+		}
+	}
+}
+
+func TestParseLabelFilterErrors(t *testing.T) {
+	for expr, message := range map[string]string{
+		"":             "expected a label",
+		"a &&":         "expected a label",
+		"(a || b":      "expected )",
+		"any(a b)":     "expected , or )",
+		"a b":          `unexpected "b"`,
+		"a & b":        `unexpected "& b"`,
+		"!(a) && (b))": `unexpected ")"`,
+	} {
+		_, err := parseLabelFilter(expr)
+		if err == nil || !strings.HasSuffix(err.Error(), message) {
+			t.Fatalf("Failed: %q gave %v", expr, err)
+		}
+	}
+}
+
+func TestLabelFilter(t *testing.T) {
+	*labelFilterParam = "integration && !slow"
+	parseFlags()
+	defer func() {
+		*labelFilterParam = ""
+		parseFlags()
+	}()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Labels", func() {
+		g.Describe("Integration", func() {
+			g.It("Should run", func() {})
+			g.It("Should not run when slow", func() {}, Label("slow"))
+		}, Label("integration"))
+		g.It("Should not run without labels", func() {})
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should run"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+}
@@ -0,0 +1,41 @@
+package goblin
+
+import "fmt"
+
+// Matcher is a reusable, domain-specific assertion which can be used with
+// Assertion.Should and Assertion.ShouldNot, e.g. one shipped by a library
+// checking HTTP responses.
+type Matcher interface {
+	// Match returns whether actual matches, or an error if it can't be
+	// matched at all, e.g. because it's of the wrong type
+	Match(actual interface{}) (bool, error)
+	// FailureMessage explains why actual doesn't match, for Should
+	FailureMessage(actual interface{}) string
+	// NegatedFailureMessage explains why actual matches, for ShouldNot
+	NegatedFailureMessage(actual interface{}) string
+}
+
+// Should asserts that the source matches m. The failure message of m is used
+// in the displayed message if the assertion fails.
+func (a *Assertion) Should(m Matcher, messages ...interface{}) {
+	a.match(m, true, messages)
+}
+
+// ShouldNot asserts that the source doesn't match m. The negated failure
+// message of m is used in the displayed message if the assertion fails.
+func (a *Assertion) ShouldNot(m Matcher, messages ...interface{}) {
+	a.match(m, false, messages)
+}
+
+func (a *Assertion) match(m Matcher, expected bool, messages []interface{}) {
+	matched, err := m.Match(a.src)
+	switch {
+	case err != nil:
+		a.fail(fmt.Sprintf("%v%s", err, formatMessages(messages...)))
+	case matched == expected:
+	case expected:
+		a.fail(m.FailureMessage(a.src) + formatMessages(messages...))
+	default:
+		a.fail(m.NegatedFailureMessage(a.src) + formatMessages(messages...))
+	}
+}
@@ -0,0 +1,60 @@
+package goblin
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// evenMatcher matches even ints
+type evenMatcher struct{}
+
+func (evenMatcher) Match(actual interface{}) (bool, error) {
+	n, ok := actual.(int)
+	if !ok {
+		return false, errors.New("evenMatcher expects an int")
+	}
+	return n%2 == 0, nil
+}
+
+func (evenMatcher) FailureMessage(actual interface{}) string {
+	return fmt.Sprintf("%v is odd", actual)
+}
+
+func (evenMatcher) NegatedFailureMessage(actual interface{}) string {
+	return fmt.Sprintf("%v is even", actual)
+}
+
+func TestShould(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: 2, fail: verifier.FailFunc}
+	a.Should(evenMatcher{})
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: 3, fail: verifier.FailFunc}
+	a.Should(evenMatcher{}, "pairs")
+	verifier.VerifyMessage(t, "3 is odd, pairs")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: "2", fail: verifier.FailFunc}
+	a.Should(evenMatcher{})
+	verifier.VerifyMessage(t, "evenMatcher expects an int")
+}
+
+func TestShouldNot(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: 3, fail: verifier.FailFunc}
+	a.ShouldNot(evenMatcher{})
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: 2, fail: verifier.FailFunc}
+	a.ShouldNot(evenMatcher{})
+	verifier.VerifyMessage(t, "2 is even")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: nil, fail: verifier.FailFunc}
+	a.ShouldNot(evenMatcher{})
+	verifier.VerifyMessage(t, "evenMatcher expects an int")
+}
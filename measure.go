@@ -0,0 +1,92 @@
+package goblin
+
+import (
+	"fmt"
+	"time"
+)
+
+// MeasureReporter is implemented by reporters which report the statistics of
+// specs declared with Measure, after the spec passes or fails.
+type MeasureReporter interface {
+	ItMeasured(name string, stats *Stats)
+}
+
+// B is passed to the body of a spec declared with Measure, to leave setup out
+// of the timing of a sample.
+type B struct {
+	N       int // Index of the running sample, from 0
+	timing  bool
+	start   time.Time
+	elapsed time.Duration
+}
+
+// StartTimer resumes timing the sample. The timer starts with each sample.
+func (b *B) StartTimer() {
+	if !b.timing {
+		b.start = time.Now()
+		b.timing = true
+	}
+}
+
+// StopTimer stops timing the sample, e.g. during setup which shouldn't be
+// measured. Allocations are still counted.
+func (b *B) StopTimer() {
+	if b.timing {
+		b.elapsed += time.Since(b.start)
+		b.timing = false
+	}
+}
+
+// ResetTimer discards the time measured so far in the sample.
+func (b *B) ResetTimer() {
+	if b.timing {
+		b.start = time.Now()
+	}
+	b.elapsed = 0
+}
+
+// Measure declares a spec running h samples times and timing each sample.
+// Its statistics, such as the mean, median and p95 durations and the
+// allocations per sample, are reported along with the spec by reporters
+// implementing MeasureReporter. h can make assertions as in any spec, e.g.
+// about the result it measures:
+//
+//	g.Measure("Should parse quickly", 100, func(b *goblin.B) {
+//		g.Assert(parse(input)).IsNotNil()
+//	})
+func (g *G) Measure(name string, samples int, h func(b *B), decorators ...Decorator) {
+	args := []interface{}{func() {
+		if samples < 1 {
+			g.Fail(fmt.Sprintf("Measure needs at least one sample, got %d", samples))
+			return
+		}
+		stats := sample(samples, func(i int) time.Duration {
+			b := &B{N: i}
+			b.StartTimer()
+			h(b)
+			b.StopTimer()
+			return b.elapsed
+		})
+		if current, _ := g.active().current(); current != nil {
+			it := current.(*It)
+			it.failureMu.Lock()
+			it.measured = stats
+			it.failureMu.Unlock()
+		}
+	}}
+	for _, d := range decorators {
+		args = append(args, d)
+	}
+	g.It(name, args...)
+}
+
+// reportMeasurement reports the statistics of a spec declared with Measure,
+// if it measured them
+func (it *It) reportMeasurement(g *G) {
+	it.failureMu.RLock()
+	stats := it.measured
+	it.failureMu.RUnlock()
+	if r, ok := g.reporter.(MeasureReporter); ok && stats != nil {
+		r.ItMeasured(it.name, stats)
+	}
+}
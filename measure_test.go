@@ -0,0 +1,52 @@
+package goblin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type measureReporter struct {
+	FakeReporter
+	measured map[string]*Stats
+}
+
+func (r *measureReporter) ItMeasured(name string, stats *Stats) {
+	r.measured[name] = stats
+}
+
+func TestMeasure(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := measureReporter{measured: map[string]*Stats{}}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var indexes []int
+	g.Describe("Measure", func() {
+		g.Measure("Should time each sample", 5, func(b *B) {
+			indexes = append(indexes, b.N)
+			b.StopTimer()
+			time.Sleep(5 * time.Millisecond)
+			b.StartTimer()
+		}, Timeout(time.Second))
+
+		g.Measure("Should fail like any spec", 3, func(b *B) {
+			g.Assert(b.N).Equal(0)
+		})
+	})
+
+	if !reflect.DeepEqual(indexes, []int{0, 1, 2, 3, 4}) {
+		t.Fatalf("Failed: sample indexes %v", indexes)
+	}
+	stats := reporter.measured["Should time each sample"]
+	if stats == nil || len(stats.Samples) != 5 || stats.Max >= 5*time.Millisecond {
+		t.Fatalf("Failed: stats %v", stats)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail like any spec"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if _, ok := reporter.measured["Should fail like any spec"]; ok {
+		t.Fatalf("Failed: reported stats of the failing spec")
+	}
+}
@@ -0,0 +1,189 @@
+package goblin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+)
+
+// Server is a server which can be served on any listener, such as a
+// *grpc.Server.
+type Server interface {
+	Serve(net.Listener) error
+	Stop()
+}
+
+// ServeInMemory serves server on an in-memory listener while the specs of the
+// enclosing Describe run. Once they are done, the server is stopped and the
+// connections dialed through the listener are closed. Clients connect through
+// the returned listener's DialContext, or ConnectInMemory hands out a ready
+// client connection.
+func (g *G) ServeInMemory(server Server) *MemoryListener {
+	l := NewMemoryListener()
+	g.Before(func() {
+		go server.Serve(l)
+	})
+	g.After(func() {
+		server.Stop()
+		l.Close()
+		l.closeConns()
+	})
+	return l
+}
+
+// ConnectInMemory serves server in memory like ServeInMemory, and connects a
+// client to it with connect before the specs of the enclosing Describe run,
+// closing the client once they are done. The returned function returns the
+// client from within the specs, e.g. for gRPC:
+//
+//	g.Describe("Greeter", func() {
+//		server := grpc.NewServer()
+//		pb.RegisterGreeterServer(server, &greeter{})
+//		conn := goblin.ConnectInMemory(g, server, func(l *goblin.MemoryListener) (*grpc.ClientConn, error) {
+//			return grpc.Dial("memory", grpc.WithContextDialer(l.DialContext),
+//				grpc.WithTransportCredentials(insecure.NewCredentials()))
+//		})
+//
+//		g.It("Should greet", func() {
+//			reply, err := pb.NewGreeterClient(conn()).SayHello(ctx, &pb.HelloRequest{Name: "goblin"})
+//			...
+//		})
+//	})
+//
+// Goblin doesn't depend on gRPC, so connect dials the client, while Goblin
+// manages the server and the connection.
+func ConnectInMemory[C io.Closer](g *G, server Server, connect func(l *MemoryListener) (C, error)) func() C {
+	l := g.ServeInMemory(server)
+	var client C
+	connected := false
+	g.Before(func() error {
+		var err error
+		client, err = connect(l)
+		connected = err == nil
+		return err
+	})
+	g.After(func() {
+		if connected {
+			client.Close()
+		}
+	})
+	return func() C {
+		return client
+	}
+}
+
+// MemoryListener is a net.Listener whose connections are made in memory,
+// without using the network.
+type MemoryListener struct {
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+	mu        sync.Mutex
+	open      map[*memoryConn]struct{} // Ends of the connections which weren't closed, guarded by mu
+}
+
+// NewMemoryListener creates a MemoryListener.
+func NewMemoryListener() *MemoryListener {
+	return &MemoryListener{
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+		open:  map[*memoryConn]struct{}{},
+	}
+}
+
+var errListenerClosed = errors.New("memory listener closed")
+
+// Accept waits for and returns the next connection to the listener.
+func (l *MemoryListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, errListenerClosed
+	}
+}
+
+// Close closes the listener. Connections which were already accepted stay
+// open.
+func (l *MemoryListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.done)
+	})
+	return nil
+}
+
+// Addr returns the listener's address.
+func (l *MemoryListener) Addr() net.Addr {
+	return memoryAddr{}
+}
+
+// Dial connects to the listener.
+func (l *MemoryListener) Dial() (net.Conn, error) {
+	return l.DialContext(context.Background(), "")
+}
+
+// DialContext connects to the listener, regardless of addr. Its signature
+// matches the dialer expected by gRPC's WithContextDialer.
+func (l *MemoryListener) DialContext(ctx context.Context, addr string) (net.Conn, error) {
+	client, server := l.pipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.done:
+		client.Close()
+		server.Close()
+		return nil, errListenerClosed
+	case <-ctx.Done():
+		client.Close()
+		server.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// DialNetwork connects to the listener, regardless of network and addr. Its
+// signature matches the DialContext field of http.Transport.
+func (l *MemoryListener) DialNetwork(ctx context.Context, network, addr string) (net.Conn, error) {
+	return l.DialContext(ctx, addr)
+}
+
+// pipe creates both ends of a connection, tracked until they are closed
+func (l *MemoryListener) pipe() (client, server *memoryConn) {
+	c, s := net.Pipe()
+	client, server = &memoryConn{Conn: c, l: l}, &memoryConn{Conn: s, l: l}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.open[client] = struct{}{}
+	l.open[server] = struct{}{}
+	return client, server
+}
+
+// closeConns closes the connections which are still open
+func (l *MemoryListener) closeConns() {
+	l.mu.Lock()
+	open := l.open
+	l.open = map[*memoryConn]struct{}{}
+	l.mu.Unlock()
+	for conn := range open {
+		conn.Conn.Close()
+	}
+}
+
+// memoryConn is an end of a connection to a MemoryListener
+type memoryConn struct {
+	net.Conn
+	l *MemoryListener
+}
+
+func (c *memoryConn) Close() error {
+	c.l.mu.Lock()
+	delete(c.l.open, c)
+	c.l.mu.Unlock()
+	return c.Conn.Close()
+}
+
+type memoryAddr struct{}
+
+func (memoryAddr) Network() string { return "memory" }
+func (memoryAddr) String() string  { return "memory" }
@@ -0,0 +1,152 @@
+package goblin
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+)
+
+// echoServer writes every line it receives back
+type echoServer struct{}
+
+func (s *echoServer) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			line, _ := bufio.NewReader(conn).ReadString('\n')
+			conn.Write([]byte(line))
+		}()
+	}
+}
+
+func (s *echoServer) Stop() {}
+
+func TestServeInMemory(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var l *MemoryListener
+	g.Describe("Echo", func() {
+		l = g.ServeInMemory(&echoServer{})
+
+		g.It("Should answer in memory", func() {
+			conn, err := l.Dial()
+			g.Assert(err).IsNil()
+			defer conn.Close()
+
+			conn.Write([]byte("hello\n"))
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			g.Assert(err).IsNil()
+			g.Assert(line).Equal("hello\n")
+		})
+	})
+
+	if len(reporter.passes) != 1 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if _, err := l.Dial(); err == nil {
+		t.Fatal("Failed: listener was not closed")
+	}
+}
+
+func TestServeInMemoryClosesConnections(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var conn net.Conn
+	g.Describe("Echo", func() {
+		l := g.ServeInMemory(&echoServer{})
+		g.Before(func() error {
+			var err error
+			conn, err = l.Dial()
+			return err
+		})
+
+		g.It("Should answer in memory", func() {
+			conn.Write([]byte("hello\n"))
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			g.Assert(err).IsNil()
+			g.Assert(line).Equal("hello\n")
+		})
+	})
+
+	if len(reporter.passes) != 1 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if _, err := conn.Write([]byte("hello\n")); err == nil {
+		t.Fatal("Failed: connection was not closed")
+	}
+}
+
+func TestConnectInMemory(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var conn func() net.Conn
+	g.Describe("Echo", func() {
+		conn = ConnectInMemory(g, &echoServer{}, func(l *MemoryListener) (net.Conn, error) {
+			return l.Dial()
+		})
+
+		g.It("Should answer on the ready connection", func() {
+			conn().Write([]byte("hello\n"))
+			line, err := bufio.NewReader(conn()).ReadString('\n')
+			g.Assert(err).IsNil()
+			g.Assert(line).Equal("hello\n")
+		})
+	})
+
+	if len(reporter.passes) != 1 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if _, err := conn().Write([]byte("hello\n")); err == nil {
+		t.Fatal("Failed: connection was not closed")
+	}
+}
+
+func TestMemoryListenerDialCanceled(t *testing.T) {
+	l := NewMemoryListener()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.DialContext(ctx, ""); err != context.Canceled {
+		t.Fatalf("Failed: error %v", err)
+	}
+	if len(l.open) != 0 {
+		t.Fatalf("Failed: %d connections left open", len(l.open))
+	}
+}
+
+func TestMemoryListenerHTTP(t *testing.T) {
+	l := NewMemoryListener()
+	defer l.Close()
+	go http.Serve(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "hello")
+	}))
+
+	client := &http.Client{Transport: &http.Transport{DialContext: l.DialNetwork}}
+	resp, err := client.Get("http://memory/")
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "hello" {
+		t.Fatalf("Failed: body %q", body)
+	}
+}
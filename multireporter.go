@@ -0,0 +1,155 @@
+package goblin
+
+import (
+	"time"
+)
+
+// multiReporter reports to several reporters at once, such as the terminal
+// reporter along with a -goblin.junit file. Optional reporter interfaces are
+// forwarded to the reporters implementing them, and fall back as they would
+// for each reporter on its own.
+type multiReporter []Reporter
+
+func (m multiReporter) BeginDescribe(name string) {
+	for _, r := range m {
+		r.BeginDescribe(name)
+	}
+}
+
+func (m multiReporter) EndDescribe() {
+	for _, r := range m {
+		r.EndDescribe()
+	}
+}
+
+func (m multiReporter) Begin() {
+	for _, r := range m {
+		r.Begin()
+	}
+}
+
+func (m multiReporter) End() {
+	for _, r := range m {
+		r.End()
+	}
+}
+
+func (m multiReporter) Failure(failure *Failure) {
+	for _, r := range m {
+		r.Failure(failure)
+	}
+}
+
+func (m multiReporter) ItTook(duration time.Duration) {
+	for _, r := range m {
+		r.ItTook(duration)
+	}
+}
+
+func (m multiReporter) ItFailed(name string) {
+	for _, r := range m {
+		r.ItFailed(name)
+	}
+}
+
+func (m multiReporter) ItPassed(name string) {
+	for _, r := range m {
+		r.ItPassed(name)
+	}
+}
+
+func (m multiReporter) ItIsPending(name string) {
+	for _, r := range m {
+		r.ItIsPending(name)
+	}
+}
+
+func (m multiReporter) ItIsExcluded(name string) {
+	for _, r := range m {
+		r.ItIsExcluded(name)
+	}
+}
+
+func (m multiReporter) ItOutput(name, output string) {
+	for _, r := range m {
+		if r, ok := r.(OutputReporter); ok {
+			r.ItOutput(name, output)
+		}
+	}
+}
+
+func (m multiReporter) ItFailedAsExpected(name, reason string) {
+	for _, r := range m {
+		if xr, ok := r.(ExpectedFailureReporter); ok {
+			xr.ItFailedAsExpected(name, reason)
+		} else {
+			r.ItPassed(name)
+		}
+	}
+}
+
+func (m multiReporter) ItSkipped(name, reason string) {
+	for _, r := range m {
+		if sr, ok := r.(SkipReporter); ok {
+			sr.ItSkipped(name, reason)
+		} else {
+			r.ItIsExcluded(name)
+		}
+	}
+}
+
+func (m multiReporter) ItAttemptFailed(name string, attempt int, failure *Failure, delay time.Duration) {
+	for _, r := range m {
+		if r, ok := r.(RetryReporter); ok {
+			r.ItAttemptFailed(name, attempt, failure, delay)
+		}
+	}
+}
+
+func (m multiReporter) ItWarned(name string, warnings []string) {
+	for _, r := range m {
+		if r, ok := r.(WarningReporter); ok {
+			r.ItWarned(name, warnings)
+		}
+	}
+}
+
+func (m multiReporter) ItMeasured(name string, stats *Stats) {
+	for _, r := range m {
+		if r, ok := r.(MeasureReporter); ok {
+			r.ItMeasured(name, stats)
+		}
+	}
+}
+
+func (m multiReporter) Randomized(seed int64) {
+	for _, r := range m {
+		if r, ok := r.(SeedReporter); ok {
+			r.Randomized(seed)
+		}
+	}
+}
+
+func (m multiReporter) SpecStarted(report *SpecReport) {
+	for _, r := range m {
+		if r, ok := r.(SpecStartReporter); ok {
+			r.SpecStarted(report)
+		}
+	}
+}
+
+func (m multiReporter) SuiteDone() {
+	for _, r := range m {
+		if r, ok := r.(SuiteReporter); ok {
+			r.SuiteDone()
+		}
+	}
+}
+
+func (m multiReporter) SpecDone(report *SpecReport) {
+	for _, r := range m {
+		if r, ok := r.(SpecReporter); ok {
+			r.SpecDone(report)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package goblin
+
+import (
+	"regexp"
+	"time"
+)
+
+// Option configures a G when creating it with Goblin, in code rather than
+// with the command line flags, e.g. for every suite of a package from
+// TestMain. Options take precedence over the flags they replace.
+type Option func(g *G)
+
+// WithTimeout sets the timeout of specs without a Timeout decorator, like
+// -goblin.timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(g *G) {
+		g.timeout = timeout
+		g.defaultTimeout = timeout
+	}
+}
+
+// WithReporter reports the results to r, instead of the reporter selected
+// with -goblin.format, like SetReporter. The reports selected with
+// -goblin.junit, -goblin.summary, -goblin.test2json and
+// -goblin.github-annotations are still written alongside.
+func WithReporter(r Reporter) Option {
+	return func(g *G) {
+		g.SetReporter(r)
+	}
+}
+
+// WithRegex only runs the specs and blocks whose names match pattern, like
+// -goblin.run. It panics if pattern isn't a valid regular expression.
+func WithRegex(pattern string) Option {
+	re := regexp.MustCompile(pattern)
+	return func(g *G) {
+		g.runRegex = re
+	}
+}
+
+// WithSeed runs the specs of each block in a random order, shuffled with
+// seed, like -goblin.randomize along with -goblin.seed.
+func WithSeed(seed int64) Option {
+	return func(g *G) {
+		g.randomize(seed, g.randomizeAll)
+	}
+}
+
+// WithParallel runs up to workers specs at the same time, or as many as there
+// are CPUs if 0, like -goblin.parallel and Parallel.
+func WithParallel(workers int) Option {
+	return func(g *G) {
+		g.Parallel(workers)
+	}
+}
+
+// WithoutHookTime excludes the time spent in the BeforeEach, JustBeforeEach,
+// JustAfterEach and AfterEach hooks of specs from their reported durations.
+func WithoutHookTime() Option {
+	return func(g *G) {
+		g.excludeHookTime = true
+	}
+}
@@ -0,0 +1,39 @@
+package goblin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := &seedReporter{}
+
+	g := Goblin(&fakeTest,
+		WithReporter(reporter),
+		WithTimeout(time.Second),
+		WithRegex("add"),
+		WithSeed(42),
+		WithParallel(3))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {
+			time.Sleep(20 * time.Millisecond)
+		})
+		g.It("Should add again", func() {
+			time.Sleep(20 * time.Millisecond)
+		})
+		g.It("Should subtract", func() {})
+	})
+
+	if fakeTest.Failed() || len(reporter.passes) != 2 {
+		t.Fatalf("Failed: passes %v, fails %v", reporter.passes, reporter.fails)
+	}
+	if !reflect.DeepEqual(reporter.seeds, []int64{42}) {
+		t.Fatalf("Failed: seeds %v", reporter.seeds)
+	}
+	if g.workers != 3 {
+		t.Fatalf("Failed: %d workers", g.workers)
+	}
+}
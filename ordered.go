@@ -0,0 +1,58 @@
+package goblin
+
+import (
+	"fmt"
+)
+
+// OrderedType is a constraint permitting any type supporting the < operator.
+type OrderedType interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// OrderedAssertion is an Assertion about an ordered value. Since both sides
+// of its comparisons have the same type, it can't mistake e.g. an int for a
+// float64 the way the reflection based assertions can.
+type OrderedAssertion[T OrderedType] struct {
+	src  T
+	fail func(interface{})
+}
+
+// AssertOrdered creates an OrderedAssertion about src, e.g.
+//
+//	goblin.AssertOrdered(g, latency).IsInRange(0, 100*time.Millisecond)
+func AssertOrdered[T OrderedType](g *G, src T) *OrderedAssertion[T] {
+	return &OrderedAssertion[T]{src: src, fail: g.assertionFail()}
+}
+
+// IsInRange asserts that the source is between lo and hi, inclusive.
+func (a *OrderedAssertion[T]) IsInRange(lo, hi T, messages ...interface{}) {
+	if a.src < lo || a.src > hi {
+		a.fail(fmt.Sprintf("%#v (%T) is not in range [%#v, %#v]%s", a.src, a.src, lo, hi, formatMessages(messages...)))
+	}
+}
+
+// IsOneOf asserts that the source equals one of values.
+func (a *OrderedAssertion[T]) IsOneOf(values []T, messages ...interface{}) {
+	for _, v := range values {
+		if a.src == v {
+			return
+		}
+	}
+	a.fail(fmt.Sprintf("%#v (%T) is not one of %#v%s", a.src, a.src, values, formatMessages(messages...)))
+}
+
+// IsLessThan asserts that the source is less than v.
+func (a *OrderedAssertion[T]) IsLessThan(v T, messages ...interface{}) {
+	if !(a.src < v) {
+		a.fail(fmt.Sprintf("%#v (%T) is not less than %#v%s", a.src, a.src, v, formatMessages(messages...)))
+	}
+}
+
+// IsGreaterThan asserts that the source is greater than v.
+func (a *OrderedAssertion[T]) IsGreaterThan(v T, messages ...interface{}) {
+	if !(a.src > v) {
+		a.fail(fmt.Sprintf("%#v (%T) is not greater than %#v%s", a.src, a.src, v, formatMessages(messages...)))
+	}
+}
@@ -0,0 +1,98 @@
+package goblin
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIsInRange(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := OrderedAssertion[int]{src: 3, fail: verifier.FailFunc}
+	a.IsInRange(1, 3)
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = OrderedAssertion[int]{src: 4, fail: verifier.FailFunc}
+	a.IsInRange(1, 3, "too big")
+	verifier.VerifyMessage(t, "4 (int) is not in range [1, 3], too big")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	d := OrderedAssertion[time.Duration]{src: time.Second, fail: verifier.FailFunc}
+	d.IsInRange(0, time.Millisecond)
+	verifier.VerifyMessage(t, "1000000000 (time.Duration) is not in range [0, 1000000]")
+}
+
+func TestIsOneOf(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := OrderedAssertion[string]{src: "b", fail: verifier.FailFunc}
+	a.IsOneOf([]string{"a", "b"})
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = OrderedAssertion[string]{src: "c", fail: verifier.FailFunc}
+	a.IsOneOf([]string{"a", "b"})
+	verifier.VerifyMessage(t, `"c" (string) is not one of []string{"a", "b"}`)
+}
+
+func TestIsLessAndGreaterThan(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := OrderedAssertion[float64]{src: 1.5, fail: verifier.FailFunc}
+	a.IsLessThan(2)
+	a.IsGreaterThan(1)
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = OrderedAssertion[float64]{src: 2, fail: verifier.FailFunc}
+	a.IsLessThan(2)
+	verifier.VerifyMessage(t, "2 (float64) is not less than 2")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = OrderedAssertion[float64]{src: 1, fail: verifier.FailFunc}
+	a.IsGreaterThan(1)
+	verifier.VerifyMessage(t, "1 (float64) is not greater than 1")
+}
+
+func TestAssertOrdered(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Ordered", func() {
+		g.It("Should be in range", func() {
+			AssertOrdered(g, 2).IsInRange(1, 3)
+		})
+		g.It("Should not be in range", func() {
+			AssertOrdered(g, 2.5).IsInRange(1, 2)
+		})
+	})
+
+	if len(reporter.passes) != 1 || len(reporter.fails) != 1 {
+		t.Fatalf("Failed: passes %v, fails %v", reporter.passes, reporter.fails)
+	}
+}
+
+func TestAssertOrderedSoft(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	reached := false
+	g.Describe("Ordered", func() {
+		g.It("Should go on after failing", func() {
+			AssertOrdered(g, 2.5).IsInRange(1, 2)
+			reached = true
+		}, Soft)
+	})
+
+	if !reached || len(reporter.fails) != 1 {
+		t.Fatalf("Failed: reached %v, fails %v", reached, reporter.fails)
+	}
+	if !strings.HasSuffix(reporter.captured[0].File, "ordered_test.go") {
+		t.Fatalf("Failed: failure at %s:%d", reporter.captured[0].File, reporter.captured[0].Line)
+	}
+}
@@ -0,0 +1,117 @@
+package goblin
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogRecord is a log message captured while a test ran.
+type LogRecord struct {
+	Time    time.Time
+	Level   string
+	Message string
+}
+
+// specOutput buffers everything a test logs while it runs
+type specOutput struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	logs []LogRecord
+}
+
+func (o *specOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.Write(p)
+}
+
+func (o *specOutput) addLog(record LogRecord) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.logs = append(o.logs, record)
+}
+
+func (o *specOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.buf.String()
+}
+
+func (o *specOutput) records() []LogRecord {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]LogRecord(nil), o.logs...)
+}
+
+// OutputReporter is implemented by reporters which display the output of
+// passing tests when running in verbose mode. The output of failing tests is
+// always available in the Failure.
+type OutputReporter interface {
+	ItOutput(name, output string)
+}
+
+// Writer returns a writer for debug output. Whatever is written while a spec
+// runs is buffered and only displayed if the spec fails or when running in
+// verbose mode. Output written outside of a spec is printed straight away.
+func (g *G) Writer() io.Writer {
+	return specWriter{g}
+}
+
+// specWriter writes to the output of the spec running at the time of writing
+type specWriter struct {
+	g *G
+}
+
+func (w specWriter) Write(p []byte) (int, error) {
+	g := w.g.active()
+	g.mutex.Lock()
+	output := g.output
+	g.mutex.Unlock()
+	if output == nil {
+		return os.Stdout.Write(p)
+	}
+	return output.Write(p)
+}
+
+// logSink is where captured logs are routed: the output of the running spec,
+// or, while specs run in parallel, of whichever spec is logging
+type logSink interface {
+	io.Writer
+	addLog(record LogRecord)
+}
+
+// captureSlog routes the default log/slog logger into the output, returning
+// the function restoring it. It is only available on Go 1.21 and later.
+var captureSlog func(o logSink) (restore func())
+
+// CaptureLogs routes the output of the standard log package, and of log/slog
+// on Go 1.21 and later, into the output of the test being run. The output is
+// only displayed if the test fails or when running in verbose mode, so green
+// runs aren't polluted by log lines.
+func (g *G) CaptureLogs() {
+	g.captureLogs = true
+}
+
+// captureLogs routes the standard loggers into o, returning the function
+// restoring them
+func captureLogs(o logSink) (restore func()) {
+	writer, flags := log.Writer(), log.Flags()
+	restoreSlog := func() {}
+	if captureSlog != nil {
+		// The log package is routed through the slog handler, which records
+		// its messages at the info level
+		restoreSlog = captureSlog(o)
+	} else {
+		log.SetOutput(o)
+	}
+
+	return func() {
+		restoreSlog()
+		log.SetOutput(writer)
+		log.SetFlags(flags)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build go1.21
+
+package goblin
+
+import (
+	"context"
+	"log/slog"
+)
+
+func init() {
+	captureSlog = func(o logSink) (restore func()) {
+		previous := slog.Default()
+		handler := slog.NewTextHandler(o, &slog.HandlerOptions{Level: slog.LevelDebug})
+		slog.SetDefault(slog.New(&recordingHandler{Handler: handler, output: o}))
+		return func() {
+			slog.SetDefault(previous)
+		}
+	}
+}
+
+// recordingHandler records each log message with its level in the test's
+// output before handing it on
+type recordingHandler struct {
+	slog.Handler
+	output logSink
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.output.addLog(LogRecord{Time: r.Time, Level: r.Level.String(), Message: r.Message})
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *recordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recordingHandler{Handler: h.Handler.WithAttrs(attrs), output: h.output}
+}
+
+func (h *recordingHandler) WithGroup(name string) slog.Handler {
+	return &recordingHandler{Handler: h.Handler.WithGroup(name), output: h.output}
+}
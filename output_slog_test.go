@@ -0,0 +1,43 @@
+//go:build go1.21
+
+package goblin
+
+import (
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCaptureSlog(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	g.CaptureLogs()
+
+	previous := slog.Default()
+	g.Describe("Logging", func() {
+		g.It("Should capture slog records", func() {
+			slog.Warn("disk almost full", "free", "1%")
+			log.Print("plain log")
+			g.Fail("failed")
+		}, Timeout(time.Second))
+	})
+
+	if len(reporter.captured) != 1 {
+		t.Fatalf("Failed: %d failures", len(reporter.captured))
+	}
+	failure := reporter.captured[0]
+	if !strings.Contains(failure.Output, "level=WARN") || !strings.Contains(failure.Output, "free=1%") {
+		t.Fatalf("Failed: output %q", failure.Output)
+	}
+	if len(failure.Logs) != 2 || failure.Logs[0].Level != "WARN" || failure.Logs[1].Message != "plain log" {
+		t.Fatalf("Failed: logs %+v", failure.Logs)
+	}
+	if slog.Default() != previous {
+		t.Fatal("Failed: slog default was not restored")
+	}
+}
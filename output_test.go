@@ -0,0 +1,100 @@
+package goblin
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+type outputReporter struct {
+	FakeReporter
+	captured []*Failure
+	outputs  []string
+}
+
+func (r *outputReporter) Failure(failure *Failure) {
+	r.FakeReporter.Failure(failure)
+	r.captured = append(r.captured, failure)
+}
+
+func (r *outputReporter) ItOutput(name, output string) {
+	r.outputs = append(r.outputs, output)
+}
+
+func TestCaptureLogs(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	g.CaptureLogs()
+
+	g.Describe("Logging", func() {
+		g.BeforeEach(func() {
+			log.Print("setting up")
+		})
+		g.It("Should keep the logs of a failing test", func() {
+			log.Print("about to fail")
+			g.Fail("failed")
+		}, Timeout(time.Second))
+	})
+
+	if len(reporter.captured) != 1 {
+		t.Fatalf("Failed: %d failures", len(reporter.captured))
+	}
+	output := reporter.captured[0].Output
+	if !strings.Contains(output, "setting up") || !strings.Contains(output, "about to fail") {
+		t.Fatalf("Failed: output %q", output)
+	}
+	if log.Writer() != os.Stderr {
+		t.Fatal("Failed: log output was not restored")
+	}
+}
+
+func TestLogsNotCaptured(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Logging", func() {
+		g.It("Should leave the logs alone", func() {
+			if log.Writer() != os.Stderr {
+				g.Fail("log output was captured")
+			}
+		}, Timeout(time.Second))
+	})
+
+	if len(reporter.fails) != 0 {
+		t.Fatalf("Failed: %v", reporter.fails)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Writer", func() {
+		g.It("Should buffer the output of a failing test", func() {
+			fmt.Fprintln(g.Writer(), "debug details")
+			g.Fail("failed")
+		}, Timeout(time.Second))
+		g.It("Should keep the output of a passing test out of failures", func() {
+			fmt.Fprintln(g.Writer(), "all good")
+		}, Timeout(time.Second))
+	})
+
+	if len(reporter.captured) != 1 || reporter.captured[0].Output != "debug details\n" {
+		t.Fatalf("Failed: failures %+v", reporter.captured)
+	}
+	if testing.Verbose() && (len(reporter.outputs) != 1 || reporter.outputs[0] != "all good\n") {
+		t.Fatalf("Failed: verbose outputs %q", reporter.outputs)
+	}
+}
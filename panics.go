@@ -0,0 +1,113 @@
+package goblin
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// Panics asserts that the source, which must be a func(), panics when called.
+func (a *Assertion) Panics(messages ...interface{}) {
+	fn, ok := a.src.(func())
+	if !ok {
+		a.fail(fmt.Sprintf("%#v %s%s", a.src, "is not a func()", formatMessages(messages...)))
+		return
+	}
+	if panicked, _, _ := callRecovering(fn); !panicked {
+		a.fail(fmt.Sprintf("%s%s", "func() did not panic", formatMessages(messages...)))
+	}
+}
+
+// PanicsWith asserts that the source, which must be a func(), panics with
+// value when called. A string value also matches the message of a recovered
+// error, or the formatted recovered value. The stack of the panic is included
+// in the displayed message if the assertion fails.
+func (a *Assertion) PanicsWith(value interface{}, messages ...interface{}) {
+	fn, ok := a.src.(func())
+	if !ok {
+		a.fail(fmt.Sprintf("%#v %s%s", a.src, "is not a func()", formatMessages(messages...)))
+		return
+	}
+	panicked, recovered, stack := callRecovering(fn)
+	if !panicked {
+		a.fail(fmt.Sprintf("func() did not panic with %s%s", a.format(value), formatMessages(messages...)))
+		return
+	}
+	if !panicValueMatches(recovered, value) {
+		a.fail(fmt.Sprintf("func() panicked with %s instead of %s%s\n%s", a.format(recovered), a.format(value),
+			formatMessages(messages...), strings.Join(stack, "\n")))
+	}
+}
+
+// callRecovering calls fn, returning whether it panicked, with which value,
+// and the stack it panicked at
+func callRecovering(fn func()) (panicked bool, recovered interface{}, stack []string) {
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		// Also covers panic(nil) before Go 1.21, which recovers as nil
+		panicked, recovered = true, recover()
+		stack = panicStack(debug.Stack())
+	}()
+	fn()
+	completed = true
+	return
+}
+
+// panicStack returns the entries of a stack trace from where it panicked
+func panicStack(stack []byte) []string {
+	lines := strings.Split(string(stack), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "panic(") {
+			// Skip the panic call and its location
+			return cleanStack(stack, i+2)
+		}
+	}
+	return cleanStack(stack, 0)
+}
+
+// panicValueMatches returns whether the recovered value matches value
+func panicValueMatches(recovered, value interface{}) bool {
+	if objectsAreEqual(recovered, value) {
+		return true
+	}
+	message, ok := value.(string)
+	if !ok {
+		return false
+	}
+	if err, ok := recovered.(error); ok {
+		return err.Error() == message
+	}
+	return fmt.Sprint(recovered) == message
+}
+
+// recoverPanic, deferred by the function calling the handler of the spec,
+// turns a panic into a failure of the spec with the panicked value and the
+// stack it panicked at, so the AfterEach hooks and the other specs still run.
+// It sets panicked, if given, when the handler panicked.
+func (it *It) recoverPanic(panicked *bool) {
+	// Failed assertions exit the goroutine without a value to recover, as does
+	// panic(nil) before Go 1.21
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	stack := debug.Stack()
+	it.failed(fmt.Sprintf("panic: %v", recovered), panicStack(stack))
+	if panicked != nil {
+		*panicked = true
+	}
+}
+
+// recoverPanic, deferred by the goroutine running a Before or After hook,
+// turns a panic into the failure of the hook.
+func (f *hookFailure) recoverPanic() {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	stack := debug.Stack()
+	f.failed(fmt.Sprintf("panic: %v", recovered), panicStack(stack))
+}
@@ -0,0 +1,120 @@
+package goblin
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPanics(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: func() { panic("boom") }, fail: verifier.FailFunc}
+	a.Panics()
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: func() {}, fail: verifier.FailFunc}
+	a.Panics("should blow up")
+	verifier.VerifyMessage(t, "func() did not panic, should blow up")
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: 1, fail: verifier.FailFunc}
+	a.Panics()
+	verifier.VerifyMessage(t, "1 is not a func()")
+}
+
+func TestPanicsWith(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: func() { panic(42) }, fail: verifier.FailFunc}
+	a.PanicsWith(42)
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: true}
+	a = Assertion{src: func() { panic(errors.New("boom")) }, fail: verifier.FailFunc}
+	a.PanicsWith("boom")
+	verifier.Verify(t)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: func() {}, fail: verifier.FailFunc}
+	a.PanicsWith("boom")
+	verifier.VerifyMessage(t, `func() did not panic with "boom"`)
+
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: func() { panic("bang") }, fail: verifier.FailFunc}
+	a.PanicsWith("boom")
+	verifier.Verify(t)
+	msg := verifier.msg.(string)
+	if !strings.HasPrefix(msg, `func() panicked with "bang" instead of "boom"`+"\n") || !strings.Contains(msg, "panics_test.go") {
+		t.Fatalf("Failed: message %q", msg)
+	}
+}
+
+func TestPanicInIt(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var afterEach int
+	g.Describe("Numbers", func() {
+		g.AfterEach(func() {
+			afterEach++
+		})
+		g.It("Should panic", func() {
+			var numbers map[string]int
+			numbers["one"] = 1
+		}, Timeout(time.Second))
+		g.It("Should panic asynchronously", func(done Done) {
+			panic("boom")
+		}, Timeout(time.Second))
+		g.It("Should still run", func() {}, Timeout(time.Second))
+	})
+
+	if !fakeTest.Failed() || !reflect.DeepEqual(reporter.fails, []string{"Should panic", "Should panic asynchronously"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should still run"}) || afterEach != 3 {
+		t.Fatalf("Failed: passes %v, %d AfterEach", reporter.passes, afterEach)
+	}
+	failure := reporter.captured[0]
+	if failure.Message != "panic: assignment to entry in nil map" || !strings.HasSuffix(failure.File, "panics_test.go") {
+		t.Fatalf("Failed: failure %q at %s:%d", failure.Message, failure.File, failure.Line)
+	}
+	if reporter.captured[1].Message != "panic: boom" {
+		t.Fatalf("Failed: failure %q", reporter.captured[1].Message)
+	}
+}
+
+func TestPanicInHooks(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Numbers", func() {
+		g.Before(func() {
+			panic("before")
+		})
+		g.It("Should be skipped", func() {}, Timeout(time.Second))
+	})
+	g.Describe("Strings", func() {
+		g.BeforeEach(func() {
+			panic("before each")
+		})
+		g.It("Should fail", func() {}, Timeout(time.Second))
+	})
+
+	if !fakeTest.Failed() || !reflect.DeepEqual(reporter.fails, []string{`"before all" hook`, "Should fail"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if reporter.captured[0].Message != "panic: before" || !strings.HasSuffix(reporter.captured[0].File, "panics_test.go") {
+		t.Fatalf("Failed: failure %q at %s", reporter.captured[0].Message, reporter.captured[0].File)
+	}
+	if reporter.captured[1].Message != `"before each" hook failed: panic: before each` {
+		t.Fatalf("Failed: failure %q", reporter.captured[1].Message)
+	}
+}
@@ -0,0 +1,325 @@
+package goblin
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Parallel sets how many specs of the suite run at the same time, overriding
+// -goblin.parallel. With workers of 0 or less, as many run as there are CPUs.
+//
+// Consecutive specs of a block run concurrently, while nested blocks, and the
+// Before and After hooks of each block, still run in order. Specs marked
+// Serial, and the specs of Ordered blocks or blocks which don't
+// ContinueOnFailure, run on their own. BeforeEach and AfterEach hooks run
+// concurrently along with their specs, so must be safe to do so.
+//
+// Results are reported in the order the specs are declared. Assertions must be
+// made from the goroutine of the spec, or, from Go 1.21, from goroutines it
+// starts. Assertions which can't be attributed to a spec fail the Go test.
+func (g *G) Parallel(workers int) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	g.workers = workers
+}
+
+// parallelBatch returns the specs at the start of children which can run
+// concurrently, if there are several of them.
+func (d *Describe) parallelBatch(g *G, children []Runnable) []*It {
+	if g.workers <= 1 || d.ordered || d.failFast {
+		return nil
+	}
+
+	var batch []*It
+	for _, r := range children {
+		it, ok := r.(*It)
+		if !ok || it.serial {
+			break
+		}
+		batch = append(batch, it)
+	}
+	if len(batch) < 2 {
+		return nil
+	}
+	return batch
+}
+
+// runParallel runs the specs of batch on up to g.workers goroutines, each in a
+// fork of g, then reports their results in order. It returns whether any of
+// them failed.
+func (g *G) runParallel(batch []*It) bool {
+	registry(g) // Shared by the forks
+	g.setCurrentIt(nil)
+	atomic.AddInt32(&g.parallelRunning, 1)
+	defer atomic.AddInt32(&g.parallelRunning, -1)
+	// Forgets the goroutines which turned out not to be any fork's
+	defer g.unregister(g)
+	if g.captureLogs {
+		defer captureLogs(parallelOutput{g})()
+	}
+
+	recorders := make([]*recordingReporter, len(batch))
+	failed := make([]bool, len(batch))
+	// Each worker is a shard, numbered from 1
+	shards := make(chan int, g.workers)
+	for shard := 1; shard <= g.workers; shard++ {
+		shards <- shard
+	}
+	var wg sync.WaitGroup
+	for i, it := range batch {
+		i, it := i, it
+		recorders[i] = &recordingReporter{}
+		shard := <-shards
+		g.startShard(shard)
+		wg.Add(1)
+		go func() {
+			defer func() {
+				shards <- shard
+				wg.Done()
+			}()
+			fork := g.fork(recorders[i], shard)
+			fork.register()
+			defer g.unregister(fork)
+			failed[i] = fork.runSpec(it)
+		}()
+	}
+	wg.Wait()
+
+	anyFailed := false
+	for i := range batch {
+		recorders[i].replay(multiReporter{g.reporter})
+		anyFailed = anyFailed || failed[i]
+	}
+	return anyFailed
+}
+
+// fork creates the G running a single spec of a parallel batch in shard,
+// reporting to r
+func (g *G) fork(r Reporter, shard int) *G {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return &G{
+		t:               g.t,
+		timeout:         g.timeout,
+		defaultTimeout:  g.defaultTimeout,
+		reporter:        r,
+		clock:           g.clock,
+		excludeHookTime: g.excludeHookTime,
+		comparators:     g.comparators,
+		root:            g,
+		shard:           shard,
+	}
+}
+
+// suite returns the G the suite was started with, which g may be a fork of
+func (g *G) suite() *G {
+	if g.root != nil {
+		return g.root
+	}
+	return g
+}
+
+// register records the calling goroutine as one of the fork's, so the
+// assertions it makes through the suite's G are attributed to the fork
+func (g *G) register() {
+	if g.root == nil {
+		return
+	}
+	root := g.root
+	root.forksMu.Lock()
+	defer root.forksMu.Unlock()
+	if root.forks == nil {
+		root.forks = map[string]*G{}
+	}
+	root.forks[string(goroutineHeader())] = g
+}
+
+// unregister forgets the goroutines of fork once its spec is done
+func (g *G) unregister(fork *G) {
+	g.forksMu.Lock()
+	defer g.forksMu.Unlock()
+	for id, f := range g.forks {
+		if f == fork {
+			delete(g.forks, id)
+		}
+	}
+}
+
+// active returns the fork running the spec of the calling goroutine while
+// specs run in parallel, or g itself otherwise
+func (g *G) active() *G {
+	if atomic.LoadInt32(&g.parallelRunning) == 0 {
+		return g
+	}
+
+	id := string(goroutineHeader())
+	g.forksMu.Lock()
+	defer g.forksMu.Unlock()
+	if fork := g.forks[id]; fork != nil {
+		return fork
+	}
+	if g.forks == nil {
+		g.forks = map[string]*G{}
+	}
+	// Telling the creator takes the whole stack trace, so the answer is kept
+	// for the next assertions of the goroutine, and for the goroutines it
+	// starts in turn
+	fork := g
+	if creator := goroutineCreator(); creator != "" && g.forks[creator] != nil {
+		fork = g.forks[creator]
+	}
+	g.forks[id] = fork
+	return fork
+}
+
+// creatorsKnown reports whether stack traces tell which goroutine started the
+// calling one, which they do from Go 1.21, replaced in tests
+var creatorsKnown = func() bool {
+	creatorsFound.Do(func() {
+		found := make(chan bool)
+		go func() {
+			found <- goroutineCreator() != ""
+		}()
+		creatorsInStacks = <-found
+	})
+	return creatorsInStacks
+}
+
+var (
+	creatorsFound    sync.Once
+	creatorsInStacks bool
+)
+
+// goroutineCreator returns the header identifying the goroutine which started
+// the calling one, e.g. "goroutine 7 ", which stack traces include from Go
+// 1.21
+func goroutineCreator() string {
+	buf := make([]byte, 1<<14)
+	buf = buf[:runtime.Stack(buf, false)]
+	marker := []byte("in goroutine ")
+	i := bytes.LastIndex(buf, marker)
+	if i < 0 {
+		return ""
+	}
+	id := buf[i+len(marker):]
+	if end := bytes.IndexByte(id, '\n'); end >= 0 {
+		id = id[:end]
+	}
+	return "goroutine " + string(bytes.TrimSpace(id)) + " "
+}
+
+// parallelOutput routes captured logs into the output of the spec logging them
+type parallelOutput struct {
+	g *G
+}
+
+func (o parallelOutput) Write(p []byte) (int, error) {
+	return specWriter{o.g}.Write(p)
+}
+
+func (o parallelOutput) addLog(record LogRecord) {
+	g := o.g.active()
+	g.mutex.Lock()
+	output := g.output
+	g.mutex.Unlock()
+	if output != nil {
+		output.addLog(record)
+	}
+}
+
+// recordingReporter records what a spec running in parallel reports, to be
+// replayed once it's its turn to be reported
+type recordingReporter struct {
+	mu    sync.Mutex
+	calls []func(r multiReporter)
+}
+
+func (r *recordingReporter) record(call func(r multiReporter)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recordingReporter) replay(to multiReporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, call := range r.calls {
+		call(to)
+	}
+}
+
+func (r *recordingReporter) BeginDescribe(name string) {
+	r.record(func(to multiReporter) { to.BeginDescribe(name) })
+}
+
+func (r *recordingReporter) EndDescribe() {
+	r.record(func(to multiReporter) { to.EndDescribe() })
+}
+
+func (r *recordingReporter) Begin() {
+	r.record(func(to multiReporter) { to.Begin() })
+}
+
+func (r *recordingReporter) End() {
+	r.record(func(to multiReporter) { to.End() })
+}
+
+func (r *recordingReporter) Failure(failure *Failure) {
+	r.record(func(to multiReporter) { to.Failure(failure) })
+}
+
+func (r *recordingReporter) ItTook(duration time.Duration) {
+	r.record(func(to multiReporter) { to.ItTook(duration) })
+}
+
+func (r *recordingReporter) ItFailed(name string) {
+	r.record(func(to multiReporter) { to.ItFailed(name) })
+}
+
+func (r *recordingReporter) ItPassed(name string) {
+	r.record(func(to multiReporter) { to.ItPassed(name) })
+}
+
+func (r *recordingReporter) ItIsPending(name string) {
+	r.record(func(to multiReporter) { to.ItIsPending(name) })
+}
+
+func (r *recordingReporter) ItIsExcluded(name string) {
+	r.record(func(to multiReporter) { to.ItIsExcluded(name) })
+}
+
+func (r *recordingReporter) ItOutput(name, output string) {
+	r.record(func(to multiReporter) { to.ItOutput(name, output) })
+}
+
+func (r *recordingReporter) ItFailedAsExpected(name, reason string) {
+	r.record(func(to multiReporter) { to.ItFailedAsExpected(name, reason) })
+}
+
+func (r *recordingReporter) ItSkipped(name, reason string) {
+	r.record(func(to multiReporter) { to.ItSkipped(name, reason) })
+}
+
+func (r *recordingReporter) ItAttemptFailed(name string, attempt int, failure *Failure, delay time.Duration) {
+	r.record(func(to multiReporter) { to.ItAttemptFailed(name, attempt, failure, delay) })
+}
+
+func (r *recordingReporter) ItWarned(name string, warnings []string) {
+	r.record(func(to multiReporter) { to.ItWarned(name, warnings) })
+}
+
+func (r *recordingReporter) ItMeasured(name string, stats *Stats) {
+	r.record(func(to multiReporter) { to.ItMeasured(name, stats) })
+}
+
+func (r *recordingReporter) SpecStarted(report *SpecReport) {
+	r.record(func(to multiReporter) { to.SpecStarted(report) })
+}
+
+func (r *recordingReporter) SpecDone(report *SpecReport) {
+	r.record(func(to multiReporter) { to.SpecDone(report) })
+}
@@ -0,0 +1,132 @@
+package goblin
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallel(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	g.Parallel(3)
+
+	// Each spec waits for the others to start, which only happens if they run
+	// at the same time
+	var started sync.WaitGroup
+	started.Add(3)
+	wait := func() {
+		started.Done()
+		started.Wait()
+	}
+
+	before, after := 0, 0
+	g.Describe("Parallel", func() {
+		g.Before(func() {
+			before++
+		})
+		g.After(func() {
+			after++
+		})
+
+		g.It("Should run first", func() {
+			wait()
+		}, Timeout(time.Second))
+		g.It("Should fail", func() {
+			wait()
+			g.Assert(1).Equal(2)
+		}, Timeout(time.Second))
+		g.It("Should run last", func() {
+			wait()
+		}, Timeout(time.Second))
+	})
+
+	if before != 1 || after != 1 {
+		t.Fatalf("Failed: %d Before, %d After", before, after)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"Should run first", "Should run last"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should fail"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if failure := reporter.captured[0]; failure.TestName != "Parallel Should fail" || failure.Message != "1 does not equal 2" {
+		t.Fatalf("Failed: failure %+v", failure)
+	}
+}
+
+func TestParallelSerial(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	g.Parallel(4)
+
+	var running, overlapped int32
+	spec := func() {
+		if atomic.AddInt32(&running, 1) > 1 {
+			atomic.StoreInt32(&overlapped, 1)
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+
+	g.Describe("Serial", func() {
+		g.It("Should run alone", spec, Serial, Timeout(time.Second))
+		g.It("Should run alone too", spec, Serial, Timeout(time.Second))
+		g.Describe("Ordered", func() {
+			g.It("Should run in order", spec, Timeout(time.Second))
+			g.It("Should run in order too", spec, Timeout(time.Second))
+		}, Ordered)
+	})
+
+	if overlapped != 0 {
+		t.Fatal("Failed: serial specs overlapped")
+	}
+	if len(reporter.passes) != 4 {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+}
+
+func TestParallelUnattributedAssert(t *testing.T) {
+	known := creatorsKnown
+	creatorsKnown = func() bool { return false }
+	defer func() { creatorsKnown = known }()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&FakeReporter{}))
+	g.Parallel(2)
+
+	// Started by the test rather than by a spec, so it can't be attributed
+	assert := make(chan struct{})
+	asserted := make(chan struct{})
+	go func() {
+		defer close(asserted)
+		<-assert
+		g.Assert(1).Equal(2)
+	}()
+
+	g.Describe("Parallel", func() {
+		g.It("Should have another goroutine assert", func() {
+			close(assert)
+			<-asserted
+		}, Timeout(time.Second))
+		g.It("Should pass", func() {}, Timeout(time.Second))
+	})
+
+	if !fakeTest.Failed() {
+		t.Fatal("Failed: the unattributed assertion didn't fail the test")
+	}
+	g.forksMu.Lock()
+	defer g.forksMu.Unlock()
+	if len(g.forks) != 0 {
+		t.Fatalf("Failed: goroutines still attributed %v", g.forks)
+	}
+}
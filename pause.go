@@ -0,0 +1,47 @@
+package goblin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+var (
+	// pauseInput and pauseOutput are where pause waits for and prints to
+	pauseInput  io.Reader = os.Stdin
+	pauseOutput io.Writer = os.Stdout
+
+	// isInteractive reports whether there's someone at a terminal to resume
+	isInteractive = func() bool {
+		return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+	}
+)
+
+// isTerminal reports whether f is a character device, i.e. a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// pause prints the failure and waits for Enter to be pressed, giving the
+// developer a chance to inspect the state of the system under test or attach
+// a debugger. It does nothing when not run from a terminal.
+func pause(failure *Failure) {
+	if !isInteractive() {
+		return
+	}
+
+	fmt.Fprintf(pauseOutput, "\n  Paused after failure of %s:\n\n", failure.TestName)
+	fmt.Fprintf(pauseOutput, "    %s\n", failure.Message)
+	for _, stackItem := range failure.Stack {
+		fmt.Fprintf(pauseOutput, "    %s\n", stackItem)
+	}
+	fmt.Fprintf(pauseOutput, "\n  Attach a debugger with: dlv attach %d\n", os.Getpid())
+	fmt.Fprintf(pauseOutput, "  Press Enter to continue...")
+
+	bufio.NewReader(pauseInput).ReadString('\n')
+}
@@ -0,0 +1,44 @@
+package goblin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPauseOnFailure(t *testing.T) {
+	output := &bytes.Buffer{}
+	input := strings.NewReader("\n")
+	pauseInput, pauseOutput = input, output
+	isInteractive = func() bool { return true }
+	*pauseOnFailure = true
+	defer func() {
+		pauseInput, pauseOutput = os.Stdin, os.Stdout
+		isInteractive = func() bool { return isTerminal(os.Stdin) && isTerminal(os.Stdout) }
+		*pauseOnFailure = false
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	g.Describe("Pause", func() {
+		g.It("Should pause", func() {
+			g.Fail("broken")
+		})
+		g.It("Should not pause", func() {})
+	})
+
+	out := output.String()
+	if strings.Count(out, "Paused after failure of Pause Should pause") != 1 {
+		t.Fatalf("Failed: output %q", out)
+	}
+	if !strings.Contains(out, "broken") || !strings.Contains(out, fmt.Sprintf("dlv attach %d", os.Getpid())) {
+		t.Fatalf("Failed: output %q", out)
+	}
+	if input.Len() != 0 {
+		t.Fatal("Failed: didn't wait for input")
+	}
+}
@@ -0,0 +1,126 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// defaultPollingInterval is how often conditions are polled when no interval
+// is given
+const defaultPollingInterval = 10 * time.Millisecond
+
+// Polling asserts a condition about the value returned by a function, polled
+// until it holds or for a duration. See Eventually and Consistently.
+type Polling struct {
+	g          *G
+	poll       interface{}
+	duration   time.Duration
+	interval   time.Duration
+	eventually bool
+}
+
+// Eventually polls poll every interval, or every 10ms if interval is 0, until
+// its value passes the assertion made on the returned Polling, failing if it
+// still doesn't once timeout elapsed:
+//
+//	g.Eventually(func() int { return len(queue) }, time.Second, 0).Equal(3)
+//
+// poll must be a function without arguments returning a value, optionally
+// followed by an error. A non-nil error fails the poll it's returned by.
+func (g *G) Eventually(poll interface{}, timeout, interval time.Duration) *Polling {
+	return newPolling(g, poll, timeout, interval, true)
+}
+
+// Consistently polls poll every interval, or every 10ms if interval is 0, for
+// duration, failing as soon as its value doesn't pass the assertion made on
+// the returned Polling. poll is called like with Eventually.
+func (g *G) Consistently(poll interface{}, duration, interval time.Duration) *Polling {
+	return newPolling(g, poll, duration, interval, false)
+}
+
+func newPolling(g *G, poll interface{}, duration, interval time.Duration, eventually bool) *Polling {
+	if interval <= 0 {
+		interval = defaultPollingInterval
+	}
+	return &Polling{g: g, poll: poll, duration: duration, interval: interval, eventually: eventually}
+}
+
+// Should polls until the value passes check, for Eventually, or for as long as
+// it does, for Consistently. The check makes its assertions on the Assertion
+// it receives.
+func (p *Polling) Should(check func(a *Assertion), messages ...interface{}) {
+	deadline := p.g.clock.Now().Add(p.duration)
+	for polls := 1; ; polls++ {
+		msg := p.check(check)
+		if p.eventually && msg == nil {
+			return
+		}
+		if !p.eventually && msg != nil {
+			p.g.Fail(fmt.Sprintf("%v, on poll %d of %s%s", msg, polls, p.duration, formatMessages(messages...)))
+			return
+		}
+		if !p.g.clock.Now().Before(deadline) {
+			if p.eventually {
+				p.g.Fail(fmt.Sprintf("%v, still after polling for %s%s", msg, p.duration, formatMessages(messages...)))
+			}
+			return
+		}
+		<-p.g.clock.NewTimer(p.interval).C()
+	}
+}
+
+// check polls the value once, returning why it doesn't pass check, or nil if
+// it does
+func (p *Polling) check(check func(a *Assertion)) (msg interface{}) {
+	fn := reflect.ValueOf(p.poll)
+	t := fn.Type()
+	if fn.Kind() != reflect.Func || t.NumIn() != 0 || t.NumOut() < 1 || t.NumOut() > 2 ||
+		(t.NumOut() == 2 && t.Out(1) != reflect.TypeOf((*error)(nil)).Elem()) {
+		return fmt.Sprintf("%T can't be polled, expected a func() T or func() (T, error)", p.poll)
+	}
+
+	out := fn.Call(nil)
+	if len(out) == 2 && !out[1].IsNil() {
+		return out[1].Interface()
+	}
+	a := p.g.Assert(out[0].Interface())
+	a.fail = func(m interface{}) {
+		// Only keep the first failure of the poll
+		if msg == nil {
+			msg = m
+		}
+	}
+	check(a)
+	return msg
+}
+
+// Equal polls until the value equals dst. See Assertion.Equal.
+func (p *Polling) Equal(dst interface{}, messages ...interface{}) {
+	p.Should(func(a *Assertion) { a.Equal(dst) }, messages...)
+}
+
+// IsTrue polls until the value is true. See Assertion.IsTrue.
+func (p *Polling) IsTrue(messages ...interface{}) {
+	p.Should(func(a *Assertion) { a.IsTrue() }, messages...)
+}
+
+// IsFalse polls until the value is false. See Assertion.IsFalse.
+func (p *Polling) IsFalse(messages ...interface{}) {
+	p.Should(func(a *Assertion) { a.IsFalse() }, messages...)
+}
+
+// IsNil polls until the value is nil. See Assertion.IsNil.
+func (p *Polling) IsNil(messages ...interface{}) {
+	p.Should(func(a *Assertion) { a.IsNil() }, messages...)
+}
+
+// IsNotNil polls until the value isn't nil. See Assertion.IsNotNil.
+func (p *Polling) IsNotNil(messages ...interface{}) {
+	p.Should(func(a *Assertion) { a.IsNotNil() }, messages...)
+}
+
+// Satisfies polls until the value passes predicate. See Assertion.Satisfies.
+func (p *Polling) Satisfies(predicate func(interface{}) bool, description string, messages ...interface{}) {
+	p.Should(func(a *Assertion) { a.Satisfies(predicate, description) }, messages...)
+}
@@ -0,0 +1,86 @@
+package goblin
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventually(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Eventually", func() {
+		g.It("Should pass once the condition holds", func() {
+			var n int32
+			go func() {
+				for i := 0; i < 3; i++ {
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&n, 1)
+				}
+			}()
+			g.Eventually(func() int32 { return atomic.LoadInt32(&n) }, time.Second, time.Millisecond).Equal(int32(3))
+		}, Timeout(2*time.Second))
+
+		g.It("Should fail if the condition never holds", func() {
+			g.Eventually(func() int { return 1 }, 20*time.Millisecond, 0).Equal(2)
+		}, Timeout(time.Second))
+
+		g.It("Should fail the polls returning an error", func() {
+			g.Eventually(func() (bool, error) {
+				return true, errors.New("not ready")
+			}, 20*time.Millisecond, 0).IsTrue()
+		}, Timeout(time.Second))
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass once the condition holds"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	expected := []string{
+		"1 does not equal 2, still after polling for 20ms",
+		"not ready, still after polling for 20ms",
+	}
+	if len(reporter.captured) != len(expected) {
+		t.Fatalf("Failed: %d failures", len(reporter.captured))
+	}
+	for i, failure := range reporter.captured {
+		if failure.Message != expected[i] {
+			t.Fatalf("Failed: message %q", failure.Message)
+		}
+	}
+}
+
+func TestConsistently(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Consistently", func() {
+		g.It("Should pass while the condition holds", func() {
+			g.Consistently(func() string { return "up" }, 20*time.Millisecond, 0).Equal("up")
+		}, Timeout(time.Second))
+
+		g.It("Should fail once the condition doesn't hold", func() {
+			polls := 0
+			g.Consistently(func() int {
+				polls++
+				return polls
+			}, time.Second, time.Millisecond).Satisfies(Predicate(func(n int) bool { return n < 3 }), "fewer than 3")
+		}, Timeout(2*time.Second))
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should pass while the condition holds"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if msg := reporter.captured[0].Message; !strings.HasSuffix(msg, "does not satisfy fewer than 3, on poll 3 of 1s") {
+		t.Fatalf("Failed: message %q", msg)
+	}
+}
@@ -0,0 +1,86 @@
+package goblin
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+var (
+	reservedPorts   = map[int]bool{}
+	reservedPortsMu sync.Mutex
+)
+
+// listen opens the listeners finding free ports, replaced in tests
+var listen = net.Listen
+
+// FreePort returns a free local TCP port for the running spec. The port is
+// reserved until the spec ends, so no other spec is handed the same one, even
+// when running concurrently.
+func (g *G) FreePort() int {
+	ports := g.FreePorts(1)
+	if len(ports) == 0 {
+		return 0
+	}
+	return ports[0]
+}
+
+// FreePorts returns n distinct free local TCP ports for the running spec. See
+// FreePort.
+func (g *G) FreePorts(n int) []int {
+	g = g.active()
+	ports := make([]int, 0, n)
+	// Keep the listeners open until all ports are found so the system doesn't
+	// hand out the same port twice
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	reservedPortsMu.Lock()
+	defer reservedPortsMu.Unlock()
+	for len(ports) < n {
+		l, err := listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			releasePorts(ports)
+			// Failing only returns once the spec timed out
+			g.Fail(fmt.Sprintf("could not find a free port: %v", err))
+			return nil
+		}
+		listeners = append(listeners, l)
+
+		port := l.Addr().(*net.TCPAddr).Port
+		if reservedPorts[port] {
+			continue
+		}
+		reservedPorts[port] = true
+		ports = append(ports, port)
+	}
+
+	g.mutex.Lock()
+	g.ports = append(g.ports, ports...)
+	g.mutex.Unlock()
+	return ports
+}
+
+// releasePorts makes ports available to other specs again. The caller must
+// hold reservedPortsMu.
+func releasePorts(ports []int) {
+	for _, port := range ports {
+		delete(reservedPorts, port)
+	}
+}
+
+// releaseSpecPorts releases the ports reserved by the running spec
+func (g *G) releaseSpecPorts() {
+	g.mutex.Lock()
+	ports := g.ports
+	g.ports = nil
+	g.mutex.Unlock()
+
+	reservedPortsMu.Lock()
+	defer reservedPortsMu.Unlock()
+	releasePorts(ports)
+}
@@ -0,0 +1,79 @@
+package goblin
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFreePorts(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var ports []int
+	g.Describe("Ports", func() {
+		g.It("Should reserve distinct free ports", func() {
+			ports = g.FreePorts(3)
+			ports = append(ports, g.FreePort())
+
+			seen := map[int]bool{}
+			for _, port := range ports {
+				g.Assert(seen[port]).IsFalse()
+				seen[port] = true
+				g.Assert(reservedPorts[port]).IsTrue()
+
+				l, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+				g.Assert(err).IsNil()
+				l.Close()
+			}
+		}, Timeout(time.Second))
+	})
+
+	if len(reporter.passes) != 1 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	for _, port := range ports {
+		if reservedPorts[port] {
+			t.Fatalf("Failed: port %d was not released", port)
+		}
+	}
+}
+
+func TestFreePortsFailure(t *testing.T) {
+	listen = func(network, address string) (net.Listener, error) {
+		return nil, errors.New("too many open files")
+	}
+	defer func() { listen = net.Listen }()
+
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	port := -1
+	done := make(chan struct{})
+	g.Describe("Ports", func() {
+		g.It("Should fail", func() {
+			g.FreePort()
+		})
+		g.It("Should still fail once timed out", func() {
+			defer close(done)
+			time.Sleep(50 * time.Millisecond)
+			port = g.FreePort()
+		}, Timeout(10*time.Millisecond))
+	})
+	<-done
+
+	if len(reporter.fails) != 2 || reporter.captured[0].Message != "could not find a free port: too many open files" {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if port != 0 {
+		t.Fatalf("Failed: port %d", port)
+	}
+}
@@ -0,0 +1,116 @@
+package goblin
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
+	"strings"
+)
+
+type profileDecorator string
+
+// Profile creates a Decorator capturing a pprof profile of a spec. The kind is
+// either "cpu" or "heap". The profile is written to the package directory, in
+// a file named after the spec, e.g. Numbers_Should_add.cpu.pprof.
+//
+// A CPU profile covers only the execution of the spec. A heap profile counts
+// the allocations of the whole process since it started, so another one is
+// written before the spec runs, e.g. Numbers_Should_add.heap-base.pprof, to
+// subtract with go tool pprof -base, leaving the allocations of the spec.
+func Profile(kind string) Decorator {
+	return profileDecorator(kind)
+}
+
+func (d profileDecorator) decorate(c *specConfig) {
+	c.profiles = append(c.profiles, string(d))
+}
+
+var profileSpecRegex *regexp.Regexp
+
+// profileFileName makes a file name for a profile of kind of the spec name
+func profileFileName(name, kind string) string {
+	name = strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !(r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	}), "_")
+	return name + "." + kind + ".pprof"
+}
+
+// startProfiles starts the profiles requested for the spec, returning the
+// function stopping them and writing them out. Profiles which can't be taken
+// are reported without failing the spec.
+func (it *It) startProfiles() (stop func()) {
+	name := it.parent.name + " " + it.name
+	kinds := it.profiles
+	if profileSpecRegex != nil && profileSpecRegex.MatchString(name) {
+		kinds = append([]string{"cpu"}, kinds...)
+	}
+
+	var stops []func() error
+	seen := map[string]bool{}
+	for _, kind := range kinds {
+		if seen[kind] {
+			continue
+		}
+		seen[kind] = true
+
+		stop, err := startProfile(kind, name)
+		if err != nil {
+			fmt.Printf("goblin: could not profile %s: %v\n", name, err)
+			continue
+		}
+		stops = append(stops, stop)
+	}
+
+	return func() {
+		for _, stop := range stops {
+			if err := stop(); err != nil {
+				fmt.Printf("goblin: could not profile %s: %v\n", name, err)
+			}
+		}
+	}
+}
+
+// startProfile starts a profile of kind of the spec name
+func startProfile(kind, name string) (stop func() error, err error) {
+	path := profileFileName(name, kind)
+	switch kind {
+	case "cpu":
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			os.Remove(path)
+			return nil, err
+		}
+		return func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		}, nil
+	case "heap":
+		if err := writeHeapProfile(profileFileName(name, "heap-base")); err != nil {
+			return nil, err
+		}
+		return func() error {
+			return writeHeapProfile(path)
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown profile %q, expected cpu or heap", kind)
+}
+
+// writeHeapProfile writes the heap profile as of now to path
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
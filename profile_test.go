@@ -0,0 +1,48 @@
+package goblin
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestProfile(t *testing.T) {
+	wd, _ := os.Getwd()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	*profileSpec = "Should be profiled too"
+	parseFlags()
+	defer func() {
+		*profileSpec = ""
+		parseFlags()
+	}()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Profiles", func() {
+		g.It("Should be profiled", func() {}, Profile("cpu"), Profile("heap"), Timeout(time.Second))
+		g.It("Should be profiled too", func() {}, Timeout(time.Second))
+		g.It("Should not be profiled", func() {}, Timeout(time.Second))
+	})
+
+	for _, name := range []string{
+		"Profiles_Should_be_profiled.cpu.pprof",
+		"Profiles_Should_be_profiled.heap.pprof",
+		"Profiles_Should_be_profiled.heap-base.pprof",
+		"Profiles_Should_be_profiled_too.cpu.pprof",
+	} {
+		if info, err := os.Stat(name); err != nil || info.Size() == 0 {
+			t.Fatalf("Failed: %s was not written: %v", name, err)
+		}
+	}
+	if _, err := os.Stat("Profiles_Should_not_be_profiled.cpu.pprof"); err == nil {
+		t.Fatal("Failed: unmatched spec was profiled")
+	}
+}
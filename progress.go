@@ -0,0 +1,153 @@
+package goblin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// progressOutput is where progress dumps are written
+var progressOutput io.Writer = os.Stdout
+
+// progress describes the test currently being run
+type progress struct {
+	name      string
+	start     time.Time
+	goroutine []byte // Header of the test's goroutine stack, e.g. "goroutine 7 "
+}
+
+// startProgress records that it has started running
+func (g *G) startProgress(it *It) {
+	g.progressMu.Lock()
+	defer g.progressMu.Unlock()
+	g.running = &progress{name: it.parent.name + " " + it.name, start: g.clock.Now()}
+}
+
+// trackGoroutine records the calling goroutine as the one running the current
+// test
+func (g *G) trackGoroutine() {
+	g.progressMu.Lock()
+	defer g.progressMu.Unlock()
+	if g.running != nil {
+		g.running.goroutine = goroutineHeader()
+	}
+}
+
+// stopProgress records that the current test has finished
+func (g *G) stopProgress() {
+	g.progressMu.Lock()
+	defer g.progressMu.Unlock()
+	g.running = nil
+}
+
+// pollProgress dumps the progress of the current test once it has been
+// running for longer than after, and again every after until the returned
+// function is called, so the logs of long running tests show they are alive
+func (g *G) pollProgress(after time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(after)
+		defer ticker.Stop()
+		defer close(stopped)
+		for {
+			select {
+			case <-ticker.C:
+				g.dumpProgress(progressOutput)
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// dumpProgress writes which tests are running, for how long, and the stacks
+// of their goroutines to w
+func (g *G) dumpProgress(w io.Writer) {
+	running := g.runningTests()
+	if len(running) == 0 {
+		fmt.Fprintf(w, "\n  No test is running\n")
+		return
+	}
+
+	for _, p := range running {
+		elapsed := g.clock.Now().Sub(p.start).Round(time.Millisecond)
+		fmt.Fprintf(w, "\n  %s has been running for %s\n\n", p.name, elapsed)
+		if stack := goroutineStack(p.goroutine); stack != nil {
+			w.Write(stack)
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// runningTests returns the progress of the test g runs, or of those its forks
+// run while specs run in parallel, longest running first
+func (g *G) runningTests() []progress {
+	gs := []*G{g}
+	g.forksMu.Lock()
+	seen := map[*G]bool{}
+	for _, fork := range g.forks {
+		// Forks are recorded for each of their goroutines
+		if !seen[fork] {
+			seen[fork] = true
+			gs = append(gs, fork)
+		}
+	}
+	g.forksMu.Unlock()
+
+	var running []progress
+	for _, g := range gs {
+		g.progressMu.Lock()
+		if g.running != nil {
+			running = append(running, *g.running)
+		}
+		g.progressMu.Unlock()
+	}
+	sort.Slice(running, func(i, j int) bool {
+		return running[i].start.Before(running[j].start)
+	})
+	return running
+}
+
+// goroutineHeader returns the start of the calling goroutine's stack trace,
+// which identifies it, e.g. "goroutine 7 "
+func goroutineHeader() []byte {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	if i := bytes.IndexByte(buf, '['); i > 0 {
+		return buf[:i]
+	}
+	return nil
+}
+
+// goroutineStack returns the stack trace of the goroutine identified by header
+func goroutineStack(header []byte) []byte {
+	if header == nil {
+		return nil
+	}
+
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if bytes.HasPrefix(stack, header) {
+			return stack
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package goblin
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDumpProgress(t *testing.T) {
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	output := &bytes.Buffer{}
+	g.dumpProgress(output)
+	if !strings.Contains(output.String(), "No test is running") {
+		t.Fatalf("Failed: output %q", output.String())
+	}
+
+	output.Reset()
+	g.Describe("Progress", func() {
+		g.It("Should be dumped", func() {
+			time.Sleep(10 * time.Millisecond)
+			g.dumpProgress(output)
+		}, Timeout(time.Second))
+	})
+
+	out := output.String()
+	if !strings.Contains(out, "Progress Should be dumped has been running for") {
+		t.Fatalf("Failed: output %q", out)
+	}
+	if !strings.Contains(out, "goblin.TestDumpProgress") {
+		t.Fatalf("Failed: output doesn't contain the test's stack %q", out)
+	}
+}
+
+func TestPollProgress(t *testing.T) {
+	output := &lockedBuffer{}
+	progressOutput = output
+	*pollProgressAfter = 10 * time.Millisecond
+	defer func() {
+		progressOutput = os.Stdout
+		*pollProgressAfter = 0
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	g.Describe("Progress", func() {
+		g.It("Should report progress while running", func() {
+			time.Sleep(35 * time.Millisecond)
+		}, Timeout(time.Second))
+		g.It("Should not report progress", func() {}, Timeout(time.Second))
+	})
+
+	time.Sleep(30 * time.Millisecond)
+	out := output.String()
+	if strings.Count(out, "Progress Should report progress while running has been running for") < 2 {
+		t.Fatalf("Failed: output %q", out)
+	}
+	if strings.Contains(out, "Should not report progress") || strings.Contains(out, "No test is running") {
+		t.Fatalf("Failed: output %q", out)
+	}
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use
+type lockedBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDumpProgressParallel(t *testing.T) {
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest, WithParallel(2))
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	output := &bytes.Buffer{}
+	var started sync.WaitGroup
+	started.Add(2)
+	dumped := make(chan struct{})
+	g.Describe("Progress", func() {
+		g.It("Should dump both", func() {
+			started.Done()
+			started.Wait()
+			g.dumpProgress(output)
+			close(dumped)
+		}, Timeout(time.Second))
+		g.It("Should be dumped too", func() {
+			started.Done()
+			<-dumped
+		}, Timeout(time.Second))
+	})
+
+	out := output.String()
+	if !strings.Contains(out, "Progress Should dump both has been running for") ||
+		!strings.Contains(out, "Progress Should be dumped too has been running for") {
+		t.Fatalf("Failed: output %q", out)
+	}
+}
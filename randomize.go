@@ -0,0 +1,56 @@
+package goblin
+
+import (
+	"math/rand"
+)
+
+// SeedReporter is implemented by reporters which report the seed the order of
+// the specs was randomized with, so the order can be reproduced with
+// -goblin.seed.
+type SeedReporter interface {
+	Randomized(seed int64)
+}
+
+// randomSeed is the seed picked for the run when -goblin.seed isn't supplied,
+// shared by every suite of the package
+var randomSeed int64
+
+// randomize makes g run the specs of each block in an order shuffled with
+// seed, and the nested blocks too if all is set
+func (g *G) randomize(seed int64, all bool) {
+	g.order = rand.New(rand.NewSource(seed))
+	g.seed = seed
+	g.randomizeAll = all
+}
+
+// reportSeed reports the seed to the reporter when the order is randomized
+func (g *G) reportSeed() {
+	if g.order == nil {
+		return
+	}
+	if r, ok := g.reporter.(SeedReporter); ok {
+		r.Randomized(g.seed)
+	}
+}
+
+// shuffled returns the children of d in the order to run them. When the order
+// is randomized, specs swap places with each other, and blocks only with
+// -goblin.randomize-all. The children of Ordered blocks keep their order.
+func (d *Describe) shuffled(g *G) []Runnable {
+	if g.order == nil || d.ordered {
+		return d.children
+	}
+
+	children := append([]Runnable(nil), d.children...)
+	var positions []int
+	for i, r := range children {
+		if _, ok := r.(*Describe); !ok || g.randomizeAll {
+			positions = append(positions, i)
+		}
+	}
+	g.order.Shuffle(len(positions), func(i, j int) {
+		a, b := positions[i], positions[j]
+		children[a], children[b] = children[b], children[a]
+	})
+	return children
+}
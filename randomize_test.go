@@ -0,0 +1,71 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type seedReporter struct {
+	FakeReporter
+	seeds []int64
+}
+
+func (r *seedReporter) Randomized(seed int64) {
+	r.seeds = append(r.seeds, seed)
+}
+
+// runShuffled runs a suite with the supplied seed, returning the order its
+// specs ran in
+func runShuffled(seed int64) ([]string, *seedReporter) {
+	*randomizeSpecs, *seedParam = true, seed
+	defer func() {
+		*randomizeSpecs, *seedParam = false, 0
+	}()
+
+	fakeTest := testing.T{}
+	reporter := &seedReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(reporter))
+
+	ran := []string{}
+	record := func(name string) {
+		g.It(name, func() {
+			ran = append(ran, name)
+		})
+	}
+	g.Describe("Shuffled", func() {
+		for i := 0; i < 8; i++ {
+			record(fmt.Sprint(i))
+		}
+		g.Describe("Nested", func() {
+			record("nested")
+		})
+		g.Describe("Ordered", func() {
+			record("a")
+			record("b")
+			record("c")
+		}, Ordered)
+	})
+	return ran, reporter
+}
+
+func TestRandomize(t *testing.T) {
+	ran, reporter := runShuffled(42)
+
+	if !reflect.DeepEqual(reporter.seeds, []int64{42}) {
+		t.Fatalf("Failed: seeds %v", reporter.seeds)
+	}
+	if len(ran) != 12 || reflect.DeepEqual(ran[:8], []string{"0", "1", "2", "3", "4", "5", "6", "7"}) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	if !reflect.DeepEqual(ran[8:], []string{"nested", "a", "b", "c"}) {
+		t.Fatalf("Failed: blocks ran %v", ran[8:])
+	}
+
+	again, _ := runShuffled(42)
+	if !reflect.DeepEqual(again, ran) {
+		t.Fatalf("Failed: ran %v, then %v with the same seed", ran, again)
+	}
+}
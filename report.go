@@ -0,0 +1,109 @@
+package goblin
+
+import (
+	"runtime"
+	"time"
+)
+
+// SpecReport holds structured data about a spec which ran.
+type SpecReport struct {
+	ID       string
+	Path     []string // Names of the enclosing Describe blocks and of the spec
+	File     string   // Where the spec was declared
+	Line     int
+	Labels   []string
+	Owner    string
+	Links    []string
+	Failed   bool
+	Duration time.Duration // How long the spec took, including its hooks unless WithoutHookTime, and every attempt
+	Attempts int           // How many times the spec ran, more than once if retried
+	Output   string        // Output captured while the spec ran
+	Logs     []LogRecord   // Log messages captured while the spec ran
+	Memory   *MemoryUsage
+	Warnings []string // Recorded with Warn
+	Stats    *Stats   // Sampled by a spec declared with Measure
+}
+
+// SpecReporter is implemented by reporters which receive a SpecReport for each
+// spec once it ran, after ItPassed or ItFailed.
+type SpecReporter interface {
+	SpecDone(*SpecReport)
+}
+
+// SuiteReporter is implemented by reporters which are notified once the Go test
+// running the suite finishes, after the End of its last top-level block, e.g.
+// to complete a stream which spans all the blocks.
+type SuiteReporter interface {
+	SuiteDone()
+}
+
+// SpecStartReporter is implemented by reporters which are notified when a spec
+// starts running. Only the fields identifying the spec are set in the report.
+type SpecStartReporter interface {
+	SpecStarted(*SpecReport)
+}
+
+// MemoryUsage is the memory allocated by a spec, sampled with -goblin.memstats.
+type MemoryUsage struct {
+	AllocatedBytes uint64 // Bytes allocated, including those already freed
+	Allocations    uint64 // Number of heap objects allocated
+	HeapDelta      int64  // Change of the bytes allocated on the heap
+}
+
+// memorySample starts sampling the memory allocated by a spec, returning the
+// function ending it
+func memorySample() (end func() *MemoryUsage) {
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	return func() *MemoryUsage {
+		var after runtime.MemStats
+		runtime.ReadMemStats(&after)
+		return &MemoryUsage{
+			AllocatedBytes: after.TotalAlloc - before.TotalAlloc,
+			Allocations:    after.Mallocs - before.Mallocs,
+			HeapDelta:      int64(after.HeapAlloc) - int64(before.HeapAlloc),
+		}
+	}
+}
+
+// newReport creates the report of a spec, with only the fields identifying it
+func (it *It) newReport() *SpecReport {
+	path := append(it.parent.path(), it.name)
+	return &SpecReport{
+		ID:     it.id(),
+		Path:   path,
+		File:   it.location.file,
+		Line:   it.location.line,
+		Labels: it.labels,
+		Owner:  it.owner,
+		Links:  it.links,
+	}
+}
+
+// started notifies the reporter that the spec starts running, if it wants to
+// know
+func (it *It) started(g *G) {
+	if r, ok := g.reporter.(SpecStartReporter); ok {
+		r.SpecStarted(it.newReport())
+	}
+}
+
+// report sends the report of a spec which ran to the reporter, if it wants one
+func (it *It) report(g *G, failed bool, duration time.Duration, attempts int, memory *MemoryUsage) {
+	r, ok := g.reporter.(SpecReporter)
+	if !ok {
+		return
+	}
+	report := it.newReport()
+	report.Failed = failed
+	report.Duration = duration
+	report.Attempts = attempts
+	report.Output = it.output.String()
+	report.Logs = it.output.records()
+	report.Memory = memory
+	it.failureMu.RLock()
+	report.Warnings = append([]string(nil), it.warnings...)
+	report.Stats = it.measured
+	it.failureMu.RUnlock()
+	r.SpecDone(report)
+}
@@ -0,0 +1,67 @@
+package goblin
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type specReporter struct {
+	FakeReporter
+	reports []*SpecReport
+}
+
+func (r *specReporter) SpecDone(report *SpecReport) {
+	r.reports = append(r.reports, report)
+}
+
+func TestSpecReport(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := specReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Reports", func() {
+		g.It("Should be reported", func() {
+			g.Fail("failed")
+		}, Label("fast"), Timeout(time.Second))
+		g.It("Should not be reported")
+	})
+
+	if len(reporter.reports) != 1 {
+		t.Fatalf("Failed: reports %+v", reporter.reports)
+	}
+	report := reporter.reports[0]
+	if !reflect.DeepEqual(report.Path, []string{"Reports", "Should be reported"}) || !report.Failed ||
+		!reflect.DeepEqual(report.Labels, []string{"fast"}) || report.ID == "" || report.Memory != nil {
+		t.Fatalf("Failed: report %+v", report)
+	}
+}
+
+func TestMemStats(t *testing.T) {
+	*memStats = true
+	defer func() {
+		*memStats = false
+	}()
+
+	fakeTest := testing.T{}
+	reporter := specReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var retained [][]byte
+	g.Describe("Memory", func() {
+		g.It("Should allocate", func() {
+			for i := 0; i < 100; i++ {
+				retained = append(retained, make([]byte, 1024))
+			}
+		}, Timeout(time.Second))
+	})
+
+	memory := reporter.reports[0].Memory
+	if memory == nil || memory.AllocatedBytes < 100*1024 || memory.Allocations < 100 {
+		t.Fatalf("Failed: memory %+v", memory)
+	}
+}
@@ -2,9 +2,13 @@ package goblin
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 )
 
@@ -21,6 +25,24 @@ type Reporter interface {
 	ItIsExcluded(string)
 }
 
+// ExpectedFailureReporter is implemented by reporters which distinguish specs
+// failing as expected from passing ones.
+type ExpectedFailureReporter interface {
+	ItFailedAsExpected(name, reason string)
+}
+
+// SkipReporter is implemented by reporters which report specs skipped at run
+// time along with the reason. Other reporters report them as excluded.
+type SkipReporter interface {
+	ItSkipped(name, reason string)
+}
+
+// RetryReporter is implemented by reporters which report the failed attempts
+// of specs which are retried, along with the delay before the next attempt.
+type RetryReporter interface {
+	ItAttemptFailed(name string, attempt int, failure *Failure, delay time.Duration)
+}
+
 type TextFancier interface {
 	Red(text string) string
 	Gray(text string) string
@@ -32,41 +54,86 @@ type TextFancier interface {
 
 type DetailedReporter struct {
 	level, failed, passed, pending, excluded int
+	expectedFailures                         int
 	failures                                 []*Failure
+	warnings                                 []string
 	executionTime, totalExecutionTime        time.Duration
 	executionTimeMu                          sync.RWMutex
+	timed                                    bool // Whether executionTime is that of the spec being reported
 	fancy                                    TextFancier
+	width                                    int // Columns to fit output to, or 0 to leave it as is
+	w                                        io.Writer
+	slowest                                  int // Number of slowest specs to list in the summary
+	durations                                []specDuration
+	failuresOnly                             bool
+	headers                                  []string // Headers of the innermost blocks, not printed yet with failuresOnly
+}
+
+// SetFailuresOnly only prints the specs which fail, under the Describe blocks
+// enclosing them, leaving out passing, pending and excluded specs along with
+// the blocks without failures. The summary of the run is printed as usual. Set
+// with -goblin.failures-only.
+func (r *DetailedReporter) SetFailuresOnly(failuresOnly bool) {
+	r.failuresOnly = failuresOnly
+}
+
+// specDuration is how long a spec took, for the list of the slowest specs
+type specDuration struct {
+	name     string
+	duration time.Duration
+}
+
+// NewDetailedReporter creates a DetailedReporter writing to w, e.g. a file or
+// a buffer, with colors from fancy. Its output isn't fit to the width of the
+// terminal.
+func NewDetailedReporter(w io.Writer, fancy TextFancier) *DetailedReporter {
+	return &DetailedReporter{w: w, fancy: fancy}
 }
 
 func (r *DetailedReporter) SetTextFancier(f TextFancier) {
 	r.fancy = f
 }
 
+// SetSlowest lists the n slowest specs, along with their durations, in the
+// summary of the run. Set with -goblin.slow-report.
+func (r *DetailedReporter) SetSlowest(n int) {
+	r.slowest = n
+}
+
+// writer returns where the output is written, the standard output by default
+func (r *DetailedReporter) writer() io.Writer {
+	if r.w == nil {
+		return os.Stdout
+	}
+	return r.w
+}
+
+// TerminalFancier colors the output with the DefaultTheme.
 type TerminalFancier struct {
 }
 
 func (self *TerminalFancier) Red(text string) string {
-	return "\033[31m" + text + "\033[0m"
+	return DefaultTheme.Red(text)
 }
 
 func (self *TerminalFancier) Gray(text string) string {
-	return "\033[90m" + text + "\033[0m"
+	return DefaultTheme.Gray(text)
 }
 
 func (self *TerminalFancier) Cyan(text string) string {
-	return "\033[36m" + text + "\033[0m"
+	return DefaultTheme.Cyan(text)
 }
 
 func (self *TerminalFancier) Green(text string) string {
-	return "\033[32m" + text + "\033[0m"
+	return DefaultTheme.Green(text)
 }
 
 func (self *TerminalFancier) Yellow(text string) string {
-	return "\033[33m" + text + "\033[0m"
+	return DefaultTheme.Yellow(text)
 }
 
 func (self *TerminalFancier) WithCheck(text string) string {
-	return "\033[32m\u2713\033[0m " + text
+	return DefaultTheme.WithCheck(text)
 }
 
 func (r *DetailedReporter) getSpace() string {
@@ -77,54 +144,163 @@ func (r *DetailedReporter) Failure(failure *Failure) {
 	r.failures = append(r.failures, failure)
 }
 
+// fit truncates the name of a spec, shown after prefix columns, to fit on one
+// line along with its duration, which is aligned to the right edge. Without a
+// width, the name is left as is and the duration isn't shown.
+func (r *DetailedReporter) fit(name string, prefix int) (string, string) {
+	r.executionTimeMu.Lock()
+	took := fmt.Sprintf("%dms", r.executionTime/time.Millisecond)
+	timed := r.timed
+	r.timed = false
+	r.executionTimeMu.Unlock()
+
+	if r.width <= 0 {
+		return name, ""
+	}
+	available := r.width - len(r.getSpace()) - prefix
+	if !timed || available-len(took)-1 < 1 {
+		return r.truncate(name, prefix), ""
+	}
+	available -= len(took) + 1
+	name = truncate(name, available)
+	padding := strings.Repeat(" ", available-len([]rune(name))+1)
+	return name, padding + r.fancy.Gray(took)
+}
+
+// truncate shortens text, shown after prefix columns, to fit on one line
+func (r *DetailedReporter) truncate(text string, prefix int) string {
+	if available := r.width - len(r.getSpace()) - prefix; r.width > 0 && available > 0 {
+		return truncate(text, available)
+	}
+	return text
+}
+
+// wrap splits text, indented by indent columns, into lines fitting the width
+func (r *DetailedReporter) wrap(text string, indent int) []string {
+	if r.width-indent <= 0 {
+		return []string{text}
+	}
+	return wrap(text, r.width-indent)
+}
+
+// print prints a line about a spec, unless only failures are printed
 func (r *DetailedReporter) print(text string) {
-	fmt.Printf("%v%v\n", r.getSpace(), text)
+	if r.failuresOnly {
+		return
+	}
+	fmt.Fprintf(r.writer(), "%v%v\n", r.getSpace(), text)
 }
 
 func (r *DetailedReporter) printWithCheck(text string) {
-	fmt.Printf("%v%v\n", r.getSpace(), r.fancy.WithCheck(text))
+	r.print(r.fancy.WithCheck(text))
+}
+
+// printFailed prints the line of a failed spec, after the headers of the
+// blocks enclosing it which weren't printed yet
+func (r *DetailedReporter) printFailed(text string) {
+	for _, header := range r.headers {
+		fmt.Fprintln(r.writer(), header)
+	}
+	r.headers = nil
+	fmt.Fprintf(r.writer(), "%v%v\n", r.getSpace(), text)
 }
 
 func (r *DetailedReporter) BeginDescribe(name string) {
-	fmt.Println("")
-	r.print(name)
+	header := "\n" + r.getSpace() + r.truncate(name, 0)
 	r.level++
+	if r.failuresOnly {
+		r.headers = append(r.headers, header)
+		return
+	}
+	fmt.Fprintln(r.writer(), header)
 }
 
 func (r *DetailedReporter) EndDescribe() {
 	r.level--
+	if len(r.headers) > 0 {
+		// The block had no failures
+		r.headers = r.headers[:len(r.headers)-1]
+	}
 }
 
 func (r *DetailedReporter) ItTook(duration time.Duration) {
 	r.executionTimeMu.Lock()
 	defer r.executionTimeMu.Unlock()
 	r.executionTime = duration
+	r.timed = true
 	r.totalExecutionTime += duration
 }
 
 func (r *DetailedReporter) ItFailed(name string) {
 	r.failed++
-	r.print(r.fancy.Red(strconv.Itoa(r.failed) + ") " + name))
+	prefix := strconv.Itoa(r.failed) + ") "
+	name, took := r.fit(name, len(prefix))
+	r.printFailed(r.fancy.Red(prefix+name) + took)
 }
 
 func (r *DetailedReporter) ItPassed(name string) {
 	r.passed++
-	r.printWithCheck(r.fancy.Gray(name))
+	name, took := r.fit(name, visibleLen(r.fancy.WithCheck("")))
+	r.printWithCheck(r.fancy.Gray(name) + took)
+}
+
+func (r *DetailedReporter) ItOutput(name string, output string) {
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		r.print("  " + r.fancy.Gray(line))
+	}
+}
+
+func (r *DetailedReporter) ItFailedAsExpected(name, reason string) {
+	r.expectedFailures++
+	r.print(r.fancy.Yellow(r.truncate("- "+name+" (expected failure: "+reason+")", 0)))
+}
+
+func (r *DetailedReporter) ItSkipped(name, reason string) {
+	r.excluded++
+	r.print(r.fancy.Yellow(r.truncate("- "+name+" (skipped: "+reason+")", 0)))
+}
+
+func (r *DetailedReporter) ItAttemptFailed(name string, attempt int, failure *Failure, delay time.Duration) {
+	msg := fmt.Sprintf("- %s (attempt %d failed: %s, retrying", name, attempt, failure.Message)
+	if delay > 0 {
+		msg += " in " + delay.String()
+	}
+	r.print(r.fancy.Gray(msg + ")"))
+}
+
+func (r *DetailedReporter) ItWarned(name string, warnings []string) {
+	for _, warning := range warnings {
+		r.warnings = append(r.warnings, name+": "+warning)
+	}
+}
+
+func (r *DetailedReporter) SpecDone(report *SpecReport) {
+	if r.slowest > 0 {
+		r.durations = append(r.durations, specDuration{strings.Join(report.Path, " "), report.Duration})
+	}
+}
+
+func (r *DetailedReporter) ItMeasured(name string, stats *Stats) {
+	r.print("  " + r.fancy.Gray(stats.String()))
 }
 
 func (r *DetailedReporter) ItIsPending(name string) {
 	r.pending++
-	r.print(r.fancy.Cyan("- " + name))
+	r.print(r.fancy.Cyan(r.truncate("- "+name, 0)))
 }
 
 func (r *DetailedReporter) ItIsExcluded(name string) {
 	r.excluded++
-	r.print(r.fancy.Yellow("- " + name))
+	r.print(r.fancy.Yellow(r.truncate("- "+name, 0)))
 }
 
 func (r *DetailedReporter) Begin() {
 }
 
+func (r *DetailedReporter) Randomized(seed int64) {
+	fmt.Fprintln(r.writer(), r.fancy.Gray(fmt.Sprintf("Randomized with seed %d", seed)))
+}
+
 func (r *DetailedReporter) End() {
 	comp := fmt.Sprintf("%d tests complete", r.passed)
 
@@ -133,28 +309,129 @@ func (r *DetailedReporter) End() {
 	r.executionTimeMu.RUnlock()
 
 	//fmt.Printf("\n\n \033[32m%d tests complete\033[0m \033[90m(%d ms)\033[0m\n", r.passed, r.totalExecutionTime/time.Millisecond)
-	fmt.Printf("\n\n %v %v\n", r.fancy.Green(comp), r.fancy.Gray(t))
+	fmt.Fprintf(r.writer(), "\n\n %v %v\n", r.fancy.Green(comp), r.fancy.Gray(t))
 
 	if r.pending > 0 {
 		pend := fmt.Sprintf("%d test(s) pending", r.pending)
-		fmt.Printf(" %v\n\n", r.fancy.Cyan(pend))
+		fmt.Fprintf(r.writer(), " %v\n\n", r.fancy.Cyan(pend))
 	}
 
 	if r.excluded > 0 {
 		excl := fmt.Sprintf("%d test(s) excluded", r.excluded)
-		fmt.Printf(" %v\n\n", r.fancy.Yellow(excl))
+		fmt.Fprintf(r.writer(), " %v\n\n", r.fancy.Yellow(excl))
+	}
+
+	if r.expectedFailures > 0 {
+		xfail := fmt.Sprintf("%d test(s) failed as expected", r.expectedFailures)
+		fmt.Fprintf(r.writer(), " %v\n\n", r.fancy.Yellow(xfail))
+	}
+
+	if len(r.warnings) > 0 {
+		fmt.Fprintf(r.writer(), "%s \n\n", r.fancy.Yellow(fmt.Sprintf(" %d warning(s):", len(r.warnings))))
+		for _, warning := range r.warnings {
+			fmt.Fprintf(r.writer(), "  - %s\n", warning)
+		}
+		fmt.Fprintln(r.writer())
+	}
+
+	if r.slowest > 0 && len(r.durations) > 0 {
+		r.printSlowest()
 	}
 
 	if len(r.failures) > 0 {
-		fmt.Printf("%s \n\n", r.fancy.Red(fmt.Sprintf(" %d tests failed:", len(r.failures))))
+		fmt.Fprintf(r.writer(), "%s \n\n", r.fancy.Red(fmt.Sprintf(" %d tests failed:", len(r.failures))))
 
 	}
 
 	for i, failure := range r.failures {
-		fmt.Printf("  %d) %s:\n\n", i+1, failure.TestName)
-		fmt.Printf("    %s\n", r.fancy.Red(failure.Message))
+		fmt.Fprintf(r.writer(), "  %d) %s: %s\n\n", i+1, failure.TestName, r.fancy.Gray("["+failure.ID+"]"))
+		r.printFailure(formatFailure(failure))
 		for _, stackItem := range failure.Stack {
-			fmt.Printf("    %s\n", r.fancy.Gray(stackItem))
+			fmt.Fprintf(r.writer(), "    %s\n", r.fancy.Gray(stackItem))
 		}
+		for _, additional := range failure.Additional {
+			fmt.Fprintln(r.writer())
+			r.printFailure("Also: " + formatFailure(additional))
+			for _, stackItem := range additional.Stack {
+				fmt.Fprintf(r.writer(), "    %s\n", r.fancy.Gray(stackItem))
+			}
+		}
+		if failure.Output != "" {
+			fmt.Fprintf(r.writer(), "\n    Output:\n")
+			for _, line := range strings.Split(strings.TrimRight(failure.Output, "\n"), "\n") {
+				fmt.Fprintf(r.writer(), "      %s\n", r.fancy.Gray(line))
+			}
+		}
+	}
+}
+
+// printSlowest prints the slowest specs, from the slowest down
+func (r *DetailedReporter) printSlowest() {
+	sort.SliceStable(r.durations, func(i, j int) bool {
+		return r.durations[i].duration > r.durations[j].duration
+	})
+	slowest := r.durations
+	if len(slowest) > r.slowest {
+		slowest = slowest[:r.slowest]
+	}
+
+	fmt.Fprintf(r.writer(), "%s \n\n", r.fancy.Yellow(fmt.Sprintf(" %d slowest test(s):", len(slowest))))
+	for _, spec := range slowest {
+		took := fmt.Sprintf("%6dms", spec.duration/time.Millisecond)
+		fmt.Fprintf(r.writer(), "  %s %s\n", r.fancy.Gray(took), spec.name)
+	}
+	fmt.Fprintln(r.writer())
+}
+
+// printFailure prints a failure message in red, except for the diff of
+// expected and actual values in it, whose removed lines are red, added lines
+// green and unchanged lines gray
+func (r *DetailedReporter) printFailure(message string) {
+	inDiff := false
+	for _, line := range r.wrap(message, 4) {
+		if line == "--- expected" {
+			inDiff = true
+		}
+		switch {
+		case !inDiff || strings.HasPrefix(line, "-"):
+			line = r.fancy.Red(line)
+		case strings.HasPrefix(line, "+"):
+			line = r.fancy.Green(line)
+		default:
+			line = r.fancy.Gray(line)
+		}
+		fmt.Fprintf(r.writer(), "    %s\n", line)
+	}
+}
+
+// locationTemplate formats failure messages along with their location, set
+// with -goblin.location-format
+var locationTemplate *template.Template
+
+// failureLocationData is what a -goblin.location-format template is executed
+// with
+type failureLocationData struct {
+	File     string
+	Line     int
+	FuncName string
+	Message  string
+	TestName string
+}
+
+// formatFailure returns the message of failure, formatted with its location if
+// a location format was supplied
+func formatFailure(failure *Failure) string {
+	if locationTemplate == nil {
+		return failure.Message
+	}
+	if failure.File == "" {
+		return failure.Message
+	}
+
+	var b strings.Builder
+	data := failureLocationData{File: failure.File, Line: failure.Line, FuncName: failure.FuncName, Message: failure.Message, TestName: failure.TestName}
+	if err := locationTemplate.Execute(&b, data); err != nil {
+		return failure.Message
 	}
+	return b.String()
 }
@@ -1,7 +1,11 @@
 package goblin
 
 import (
+	"bytes"
+	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -197,3 +201,121 @@ func TestReportingErrors(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestLocationFormat(t *testing.T) {
+	fakeTest := &testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var line int
+	g.Describe("Numbers", func() {
+		g.It("Should point at the assertion", func() {
+			_, _, line, _ = runtime.Caller(0)
+			g.Assert(0).Equal(1)
+		}, Timeout(time.Second))
+	})
+
+	*locationFormat = "{{.File}}:{{.Line}}: {{.Message}}"
+	parseFlags()
+	defer func() {
+		*locationFormat = ""
+		parseFlags()
+	}()
+
+	_, file, _, _ := runtime.Caller(0)
+	expected := fmt.Sprintf("%s:%d: 0 does not equal 1", file, line+1)
+	if message := formatFailure(reporter.captured[0]); message != expected {
+		t.Fatalf("Failed: %q, expected %q", message, expected)
+	}
+}
+
+func TestDetailedReporterWriter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewDetailedReporter(&out, &Monochrome{})))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.It("Should subtract", func() {
+			g.Fail("failed")
+		}, Timeout(time.Second))
+	})
+
+	for _, expected := range []string{"  Numbers\n", "Should add\n", "1) Should subtract\n", "1 tests complete", "1 tests failed:", "    !failed\n"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Fatalf("Failed: %q in output\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestReportingSlowest(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	reporter := NewDetailedReporter(&out, &Monochrome{})
+	reporter.SetSlowest(2)
+	g.SetReporter(Reporter(reporter))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.It("Should subtract", func() {
+			time.Sleep(30 * time.Millisecond)
+		}, Timeout(time.Second))
+		g.It("Should multiply", func() {
+			time.Sleep(15 * time.Millisecond)
+		}, Timeout(time.Second))
+	})
+
+	_, summary, _ := strings.Cut(out.String(), "2 slowest test(s):")
+	lines := strings.Split(strings.TrimSpace(summary), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "ms Numbers Should subtract") || !strings.HasSuffix(lines[1], "ms Numbers Should multiply") {
+		t.Fatalf("Failed: summary\n%s", out.String())
+	}
+}
+
+func TestReportingFailuresOnly(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	reporter := NewDetailedReporter(&out, &Monochrome{})
+	reporter.SetFailuresOnly(true)
+	g.SetReporter(Reporter(reporter))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.Describe("Passing", func() {
+			g.It("Should subtract", func() {}, Timeout(time.Second))
+		})
+		g.Describe("Failing", func() {
+			g.Describe("Nested", func() {
+				g.It("Should multiply", func() {
+					g.Fail("failed")
+				}, Timeout(time.Second))
+				g.It("Should divide")
+			})
+		})
+	})
+
+	output, _, _ := strings.Cut(out.String(), "\n\n 2 tests complete")
+	expected := []string{
+		"",
+		"  Numbers",
+		"",
+		"    Failing",
+		"",
+		"      Nested",
+		"        !1) Should multiply",
+	}
+	if !reflect.DeepEqual(strings.Split(strings.TrimRight(output, "\n"), "\n"), expected) {
+		t.Fatalf("Failed: output\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "1 tests failed:") {
+		t.Fatalf("Failed: summary\n%s", out.String())
+	}
+}
@@ -1,7 +1,10 @@
 package goblin
 
 import (
+	"path/filepath"
+	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
 )
 
@@ -19,3 +22,63 @@ func cleanStack(stack []byte, skip int) []string {
 	}
 	return finalStack
 }
+
+// parseStackLine returns the file and line of an entry of a resolved stack,
+// such as "\t/src/foo_test.go:12 +0x1d"
+func parseStackLine(entry string) (file string, line int, ok bool) {
+	entry = strings.TrimSpace(entry)
+	if i := strings.LastIndex(entry, " +0x"); i >= 0 {
+		entry = entry[:i]
+	}
+	i := strings.LastIndex(entry, ":")
+	if i < 0 {
+		return "", 0, false
+	}
+	line, err := strconv.Atoi(entry[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return entry[:i], line, true
+}
+
+// locate sets the location of the failing assertion from the stack of failure
+func (failure *Failure) locate() {
+	file, line, ok := failureLocation(failure.Stack)
+	if !ok {
+		return
+	}
+	failure.File, failure.Line = file, line
+	failure.FuncName = callerFunction(file, line)
+}
+
+// callerFunction returns the function of the frame of the calling goroutine at
+// file and line, or "" if there's none, e.g. when the failure isn't built by
+// the goroutine making the failing assertion
+func callerFunction(file string, line int) string {
+	pcs := make([]uintptr, 128)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if frame.File == file && frame.Line == line {
+			return frame.Function
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// failureLocation returns the file and line of the first entry of stack which
+// is outside of goblin, i.e. where the failing assertion was made
+func failureLocation(stack []string) (file string, line int, ok bool) {
+	for _, entry := range stack {
+		file, line, ok := parseStackLine(entry)
+		if !ok {
+			continue
+		}
+		if filepath.Dir(file) != goblinDir || strings.HasSuffix(file, "_test.go") {
+			return file, line, true
+		}
+	}
+	return "", 0, false
+}
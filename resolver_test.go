@@ -1,7 +1,9 @@
 package goblin
 
 import (
+	"runtime"
 	"testing"
+	"time"
 )
 
 func TestResolver(t *testing.T) {
@@ -18,3 +20,58 @@ func dummyFunc(g *G) {
 	stack := ResolveStack(5)
 	g.Assert(len(stack)).Equal(6)
 }
+
+func TestFailureLocation(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var line int
+	g.Describe("Numbers", func() {
+		g.It("Should point at the assertion", func() {
+			_, _, line, _ = runtime.Caller(0)
+			g.Assert(0).Equal(1)
+		}, Timeout(time.Second))
+	})
+
+	_, file, _, _ := runtime.Caller(0)
+	failure := reporter.captured[0]
+	if failure.File != file || failure.Line != line+1 {
+		t.Fatalf("Failed: location %s:%d", failure.File, failure.Line)
+	}
+	if failure.FuncName != "github.com/shakefu/goblin.TestFailureLocation.func1.1" {
+		t.Fatalf("Failed: function %q", failure.FuncName)
+	}
+}
+
+func TestFailureLocationOfHooksAndPanics(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Hooks", func() {
+		g.Before(func() {
+			g.Assert(0).Equal(1)
+		})
+		g.It("Should be skipped", func() {}, Timeout(time.Second))
+	})
+	g.Describe("Panics", func() {
+		g.It("Should point at the panic", func() {
+			panic("boom")
+		}, Timeout(time.Second))
+	})
+
+	if len(reporter.captured) != 2 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if f := reporter.captured[0].FuncName; f != "github.com/shakefu/goblin.TestFailureLocationOfHooksAndPanics.func1.1" {
+		t.Fatalf("Failed: function %q", f)
+	}
+	if f := reporter.captured[1].FuncName; f != "github.com/shakefu/goblin.TestFailureLocationOfHooksAndPanics.func2.1" {
+		t.Fatalf("Failed: function %q", f)
+	}
+}
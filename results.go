@@ -0,0 +1,164 @@
+package goblin
+
+import (
+	"sync"
+	"time"
+)
+
+// SpecStatus is the outcome of a spec.
+type SpecStatus string
+
+const (
+	SpecPassed           SpecStatus = "passed"
+	SpecFailed           SpecStatus = "failed"
+	SpecPending          SpecStatus = "pending"
+	SpecExcluded         SpecStatus = "excluded"
+	SpecSkipped          SpecStatus = "skipped"
+	SpecFailedAsExpected SpecStatus = "failed as expected"
+)
+
+// SuiteResult is the outcome of a Describe block, with those of its specs and
+// nested blocks in the order they were reported.
+type SuiteResult struct {
+	Name     string
+	Specs    []*SpecResult // Including Before and After hooks which failed
+	Blocks   []*SuiteResult
+	Duration time.Duration // Time taken by the specs of the block and its nested blocks
+}
+
+// SpecResult is the outcome of a spec.
+type SpecResult struct {
+	Name     string
+	Status   SpecStatus
+	Duration time.Duration
+	Failure  *Failure // Set when the spec failed
+	Reason   string   // Why the spec was skipped or expected to fail
+}
+
+// Failed returns whether a spec of the block or of its nested blocks failed.
+func (s *SuiteResult) Failed() bool {
+	for _, spec := range s.Specs {
+		if spec.Status == SpecFailed {
+			return true
+		}
+	}
+	for _, block := range s.Blocks {
+		if block.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Results returns the results of the top-level Describe blocks which ran so
+// far, in the order they ran, e.g. to inspect the outcome of a suite run from
+// a test of a test harness:
+//
+//	g.Describe("Numbers", func() { ... })
+//	if results := g.Results(); results[0].Failed() { ... }
+func (g *G) Results() []*SuiteResult {
+	g = g.suite()
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return append([]*SuiteResult(nil), g.results...)
+}
+
+// collectResults adds the reporter building the results of top-level blocks
+// to the reporter, unless it's there already, and returns it. It's only added
+// again if SetReporter replaced the reporter since, as goroutines of specs
+// which timed out may still be using it.
+func (g *G) collectResults() *resultsReporter {
+	if g.collecting == nil || g.reporter != g.collecting {
+		g.collector = &resultsReporter{}
+		g.collecting = &multiReporter{g.reporter, g.collector}
+		g.reporter = g.collecting
+	}
+	return g.collector
+}
+
+// resultsReporter builds the SuiteResult of a top-level block from the
+// events reported while it runs
+type resultsReporter struct {
+	root   *SuiteResult
+	blocks []*SuiteResult // Enclosing the spec being reported
+	took   time.Duration  // Of the spec being reported
+	last   *SpecResult
+	mu     sync.Mutex // Durations are reported from the goroutines of specs
+}
+
+// add adds the result of a spec to the innermost block and its parents
+func (r *resultsReporter) add(name string, status SpecStatus, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = &SpecResult{Name: name, Status: status, Duration: r.took, Reason: reason}
+	r.took = 0
+	if len(r.blocks) == 0 {
+		return
+	}
+	block := r.blocks[len(r.blocks)-1]
+	block.Specs = append(block.Specs, r.last)
+	for _, block := range r.blocks {
+		block.Duration += r.last.Duration
+	}
+}
+
+func (r *resultsReporter) BeginDescribe(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	block := &SuiteResult{Name: name}
+	if len(r.blocks) == 0 {
+		r.root = block
+	} else {
+		parent := r.blocks[len(r.blocks)-1]
+		parent.Blocks = append(parent.Blocks, block)
+	}
+	r.blocks = append(r.blocks, block)
+}
+
+func (r *resultsReporter) EndDescribe() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blocks = r.blocks[:len(r.blocks)-1]
+}
+
+func (r *resultsReporter) Begin() {}
+
+func (r *resultsReporter) End() {}
+
+func (r *resultsReporter) Failure(failure *Failure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last != nil && r.last.Status == SpecFailed {
+		r.last.Failure = failure
+	}
+}
+
+func (r *resultsReporter) ItTook(duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.took = duration
+}
+
+func (r *resultsReporter) ItFailed(name string) {
+	r.add(name, SpecFailed, "")
+}
+
+func (r *resultsReporter) ItPassed(name string) {
+	r.add(name, SpecPassed, "")
+}
+
+func (r *resultsReporter) ItIsPending(name string) {
+	r.add(name, SpecPending, "")
+}
+
+func (r *resultsReporter) ItIsExcluded(name string) {
+	r.add(name, SpecExcluded, "")
+}
+
+func (r *resultsReporter) ItSkipped(name, reason string) {
+	r.add(name, SpecSkipped, reason)
+}
+
+func (r *resultsReporter) ItFailedAsExpected(name, reason string) {
+	r.add(name, SpecFailedAsExpected, reason)
+}
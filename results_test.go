@@ -0,0 +1,60 @@
+package goblin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResults(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {
+			time.Sleep(5 * time.Millisecond)
+		}, Timeout(time.Second))
+		g.Describe("Nested", func() {
+			g.It("Should subtract", func() {
+				g.Fail("failed")
+			}, Timeout(time.Second))
+			g.It("Should multiply")
+			g.Xit("Should divide", func() {})
+		})
+	})
+	g.Describe("Strings", func() {
+		g.It("Should concatenate", func() {}, Timeout(time.Second))
+	})
+
+	results := g.Results()
+	if len(results) != 2 || results[0].Name != "Numbers" || results[1].Name != "Strings" {
+		t.Fatalf("Failed: results %+v", results)
+	}
+	numbers := results[0]
+	if !numbers.Failed() || results[1].Failed() {
+		t.Fatalf("Failed: Failed() %t, %t", numbers.Failed(), results[1].Failed())
+	}
+	if len(numbers.Specs) != 1 || numbers.Specs[0].Status != SpecPassed || numbers.Specs[0].Duration < 5*time.Millisecond {
+		t.Fatalf("Failed: specs %+v", numbers.Specs)
+	}
+	if numbers.Duration < numbers.Specs[0].Duration {
+		t.Fatalf("Failed: duration %s", numbers.Duration)
+	}
+
+	nested := numbers.Blocks[0]
+	var statuses []SpecStatus
+	for _, spec := range nested.Specs {
+		statuses = append(statuses, spec.Status)
+	}
+	if nested.Name != "Nested" || len(statuses) != 3 || statuses[0] != SpecFailed || statuses[1] != SpecPending || statuses[2] != SpecExcluded {
+		t.Fatalf("Failed: nested %s %v", nested.Name, statuses)
+	}
+	if failure := nested.Specs[0].Failure; failure == nil || failure.Message != "failed" {
+		t.Fatalf("Failed: failure %+v", failure)
+	}
+	if len(reporter.passes) != 2 || len(reporter.fails) != 1 {
+		t.Fatalf("Failed: the reporter should still be notified")
+	}
+}
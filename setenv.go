@@ -0,0 +1,29 @@
+package goblin
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// Setenv sets the environment variable key to value until the spec or block
+// being run finishes, restoring its previous value, or unsetting it, as if
+// with Cleanup. Since the environment is shared by the whole process, it fails
+// specs running in parallel, which should be marked with Serial instead.
+func (g *G) Setenv(key, value string) {
+	if atomic.LoadInt32(&g.suite().parallelRunning) > 0 {
+		g.Fail(fmt.Sprintf("Setenv(%q) can't be used by specs running in parallel, mark the spec with goblin.Serial", key))
+	}
+
+	previous, set := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		g.Fail(fmt.Sprintf("can't set %s: %v", key, err))
+	}
+	g.Cleanup(func() {
+		if set {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
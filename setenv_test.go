@@ -0,0 +1,75 @@
+package goblin
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetenv(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	os.Setenv("GOBLIN_SET", "original")
+	defer os.Unsetenv("GOBLIN_SET")
+	os.Unsetenv("GOBLIN_UNSET")
+
+	var seen []string
+	g.Describe("Setenv", func() {
+		g.Before(func() {
+			g.Setenv("GOBLIN_SET", "block")
+		})
+
+		g.It("Should set the variable for the block", func() {
+			seen = append(seen, os.Getenv("GOBLIN_SET"))
+		})
+
+		g.It("Should set the variable for the spec", func() {
+			g.Setenv("GOBLIN_SET", "spec")
+			g.Setenv("GOBLIN_UNSET", "spec")
+			seen = append(seen, os.Getenv("GOBLIN_SET"))
+		})
+
+		g.It("Should restore the variable after the spec", func() {
+			_, set := os.LookupEnv("GOBLIN_UNSET")
+			g.Assert(set).IsFalse()
+			seen = append(seen, os.Getenv("GOBLIN_SET"))
+		})
+	})
+
+	if !reflect.DeepEqual(seen, []string{"block", "spec", "block"}) || len(reporter.captured) != 0 {
+		t.Fatalf("Failed: seen %v, failures %v", seen, reporter.captured)
+	}
+	if value := os.Getenv("GOBLIN_SET"); value != "original" {
+		t.Fatalf("Failed: restored %q", value)
+	}
+}
+
+func TestSetenvParallel(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	g.Parallel(2)
+
+	g.Describe("Parallel Setenv", func() {
+		g.It("Should fail", func() {
+			g.Setenv("GOBLIN_PARALLEL", "1")
+		}, Timeout(time.Second))
+
+		g.It("Should pass", func() {}, Timeout(time.Second))
+	})
+
+	if len(reporter.captured) != 1 || reporter.captured[0].Message !=
+		`Setenv("GOBLIN_PARALLEL") can't be used by specs running in parallel, mark the spec with goblin.Serial` {
+		t.Fatalf("Failed: failures %v", reporter.captured)
+	}
+	if _, set := os.LookupEnv("GOBLIN_PARALLEL"); set {
+		t.Fatalf("Failed: set while running in parallel")
+	}
+}
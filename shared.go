@@ -0,0 +1,122 @@
+package goblin
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SharedSpecs declares the specs of a reusable bundle against the
+// implementation supplied when it is mounted.
+type SharedSpecs func(g *G, impl interface{})
+
+var (
+	sharedSpecs   = map[string]SharedSpecs{}
+	sharedSpecsMu sync.RWMutex
+)
+
+// RegisterSpecs registers a bundle of specs under name so other packages can
+// mount it into their own suite, e.g. a conformance suite for an interface:
+//
+//	func init() {
+//		goblin.RegisterSpecs("store conformance", func(g *goblin.G, impl interface{}) {
+//			store := impl.(Store)
+//			g.It("Should get what was put", func() { ... })
+//		})
+//	}
+//
+// Registering the same name twice panics.
+func RegisterSpecs(name string, specs SharedSpecs) {
+	sharedSpecsMu.Lock()
+	defer sharedSpecsMu.Unlock()
+	if _, ok := sharedSpecs[name]; ok {
+		panic(fmt.Sprintf("Specs %q are already registered.", name))
+	}
+	sharedSpecs[name] = specs
+}
+
+// Mount declares a Describe named after the registered bundle of specs and
+// runs them against impl. The bundle's specs are reported, filtered and
+// decorated like any other spec in the suite. Mounting a bundle which isn't
+// registered fails the suite.
+func (g *G) Mount(name string, impl interface{}, decorators ...Decorator) {
+	sharedSpecsMu.RLock()
+	specs, ok := sharedSpecs[name]
+	sharedSpecsMu.RUnlock()
+
+	g.Describe(name, func() {
+		if !ok {
+			g.It("Should mount registered specs", func() {
+				g.Fail(fmt.Sprintf("no specs are registered as %q", name))
+			})
+			return
+		}
+		specs(g, impl)
+	}, decorators...)
+}
+
+// Behavior is a reusable bundle of specs parameterized by a factory for the
+// subject under test. Unlike RegisterSpecs, a Behavior is type safe and is
+// exported like any other value, e.g.
+//
+//	var cacheContract = goblin.NewBehavior("cache contract",
+//		func(g *goblin.G, newCache func() Cache) {
+//			g.It("Should miss unknown keys", func() { ... })
+//		}, goblin.Label("contract"))
+//
+//	func CacheContract(g *goblin.G, factory func() Cache) {
+//		cacheContract.Mount(g, factory)
+//	}
+type Behavior[T any] struct {
+	name       string
+	specs      func(g *G, factory func() T)
+	decorators []Decorator
+}
+
+// NewBehavior creates a Behavior whose specs are declared by specs. The
+// decorators are applied to the Describe the behavior is mounted as.
+func NewBehavior[T any](name string, specs func(g *G, factory func() T), decorators ...Decorator) *Behavior[T] {
+	return &Behavior[T]{name: name, specs: specs, decorators: decorators}
+}
+
+// Mount declares the behavior's specs as a Describe of the suite, using
+// factory to create their subject. Decorators passed to Mount are applied
+// after the behavior's own, and the specs are reported, filtered and labelled
+// as if they had been declared by the suite.
+func (b *Behavior[T]) Mount(g *G, factory func() T, decorators ...Decorator) {
+	all := append(append([]Decorator(nil), b.decorators...), decorators...)
+	g.Describe(b.name, func() {
+		b.specs(g, factory)
+	}, all...)
+}
+
+// BoundBehavior is a Behavior bound to the factory of its subject, see With.
+type BoundBehavior struct {
+	mount func(g *G, decorators []Decorator)
+}
+
+// With binds the behavior to factory, which creates the subject of its specs,
+// so it can be included with ItBehavesLike.
+func (b *Behavior[T]) With(factory func() T) BoundBehavior {
+	return BoundBehavior{mount: func(g *G, decorators []Decorator) {
+		b.Mount(g, factory, decorators...)
+	}}
+}
+
+// ItBehavesLike includes the specs of a behavior in the current Describe, as a
+// nested Describe named after the behavior. Decorators are applied after the
+// behavior's own. A behavior declared once applies to every Describe including
+// it:
+//
+//	var reader = goblin.NewBehavior("implements io.Reader",
+//		func(g *goblin.G, subject func() io.Reader) {
+//			g.It("Should return io.EOF at the end", func() { ... })
+//		})
+//
+//	g.Describe("bytes.Reader", func() {
+//		g.ItBehavesLike(reader.With(func() io.Reader {
+//			return bytes.NewReader(nil)
+//		}))
+//	})
+func (g *G) ItBehavesLike(b BoundBehavior, decorators ...Decorator) {
+	b.mount(g, decorators)
+}
@@ -0,0 +1,123 @@
+package goblin
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterSpecs("stringer conformance", func(g *G, impl interface{}) {
+		s := impl.(interface{ String() string })
+		g.It("Should not be empty", func() {
+			g.Assert(s.String() != "").IsTrue()
+		})
+	})
+}
+
+type named string
+
+func (n named) String() string { return string(n) }
+
+func TestMount(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Names", func() {
+		g.Describe("Full", func() {
+			g.Mount("stringer conformance", named("goblin"))
+		})
+		g.Describe("Empty", func() {
+			g.Mount("stringer conformance", named(""))
+		})
+		g.Mount("missing", nil)
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should not be empty"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Should not be empty", "Should mount registered specs"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestRegisterSpecsTwice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Failed: registering specs twice should panic")
+		}
+	}()
+	RegisterSpecs("stringer conformance", func(g *G, impl interface{}) {})
+}
+
+var counterBehavior = NewBehavior("counter behavior", func(g *G, newCounter func() *int) {
+	g.It("Should start at zero", func() {
+		g.Assert(*newCounter()).Equal(0)
+	})
+}, Label("contract"))
+
+func TestBehavior(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var labels []string
+	g.Describe("Counter", func() {
+		counterBehavior.Mount(g, func() *int { return new(int) }, Label("fast"))
+		g.Describe("Labels", func() {
+			counterBehavior.Mount(g, func() *int {
+				labels = g.currentIt.(*It).labels
+				return new(int)
+			})
+		})
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should start at zero", "Should start at zero"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.describes, []string{"Counter", "counter behavior", "Labels", "counter behavior"}) {
+		t.Fatalf("Failed: describes %v", reporter.describes)
+	}
+	if !reflect.DeepEqual(labels, []string{"contract"}) {
+		t.Fatalf("Failed: labels %v", labels)
+	}
+}
+
+func TestItBehavesLike(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	reader := NewBehavior("implements io.Reader", func(g *G, subject func() io.Reader) {
+		g.It("Should return io.EOF at the end", func() {
+			_, err := subject().Read(make([]byte, 1))
+			g.Assert(err).Equal(io.EOF)
+		})
+	})
+
+	g.Describe("Readers", func() {
+		g.Describe("bytes.Reader", func() {
+			g.ItBehavesLike(reader.With(func() io.Reader { return bytes.NewReader(nil) }))
+		})
+		g.Describe("strings.Reader", func() {
+			g.ItBehavesLike(reader.With(func() io.Reader { return strings.NewReader("") }), Label("strings"))
+		})
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Should return io.EOF at the end", "Should return io.EOF at the end"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	expected := []string{"Readers", "bytes.Reader", "implements io.Reader", "strings.Reader", "implements io.Reader"}
+	if !reflect.DeepEqual(reporter.describes, expected) {
+		t.Fatalf("Failed: describes %v", reporter.describes)
+	}
+}
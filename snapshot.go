@@ -0,0 +1,71 @@
+package goblin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotDir is where AssertSnapshot stores snapshots
+var snapshotDir = filepath.Join("testdata", "__snapshots__")
+
+// AssertSnapshot asserts that value matches its snapshot, the golden file
+// testdata/__snapshots__/name.snap, and shows a diff of them if it doesn't.
+// Strings and []byte are stored as is, other values in Go syntax with one
+// field or element per line. Run with -goblin.update-snapshots to write the
+// snapshots from the current values instead, e.g. to create them.
+func (g *G) AssertSnapshot(name string, value interface{}, messages ...interface{}) {
+	g.Assert(value).matchSnapshot(name, messages)
+}
+
+func (a *Assertion) matchSnapshot(name string, messages []interface{}) {
+	path := filepath.Join(snapshotDir, name+".snap")
+	actual := serializeSnapshot(a.src)
+	if *updateSnapshots {
+		if err := writeSnapshot(path, actual); err != nil {
+			a.fail(fmt.Sprintf("can't update snapshot: %v%s", err, formatMessages(messages...)))
+		}
+		return
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		a.fail(fmt.Sprintf("no snapshot %s, run with -goblin.update-snapshots to create it%s", path,
+			formatMessages(messages...)))
+		return
+	}
+	if err != nil {
+		a.fail(fmt.Sprintf("can't read snapshot: %v%s", err, formatMessages(messages...)))
+		return
+	}
+
+	// Tolerate line endings converted on checkout
+	expected := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if expected == actual {
+		return
+	}
+	diff, _ := unifiedDiff(strings.Split(expected, "\n"), strings.Split(actual, "\n"))
+	a.fail(fmt.Sprintf("%#v does not match its snapshot %s%s\n%s", name, path, formatMessages(messages...), diff))
+}
+
+// serializeSnapshot renders value as stored in a snapshot
+func serializeSnapshot(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return strings.Join(renderLines(value), "\n")
+}
+
+// writeSnapshot stores a serialized value at path, ending with a newline
+func writeSnapshot(path, serialized string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(serialized+"\n"), 0o644)
+}
@@ -0,0 +1,69 @@
+package goblin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	defer func(dir string) { snapshotDir = dir }(snapshotDir)
+	snapshotDir = t.TempDir()
+	path := filepath.Join(snapshotDir, "user.snap")
+	user := diffUser{Name: "ann", Tags: []string{"a"}}
+
+	verifier := AssertionVerifier{ShouldPass: false}
+	a := Assertion{src: user, fail: verifier.FailFunc}
+	a.matchSnapshot("user", nil)
+	verifier.VerifyMessage(t, "no snapshot "+path+", run with -goblin.update-snapshots to create it")
+
+	*updateSnapshots = true
+	verifier = AssertionVerifier{ShouldPass: true}
+	a = Assertion{src: user, fail: verifier.FailFunc}
+	a.matchSnapshot("user", nil)
+	*updateSnapshots = false
+	verifier.Verify(t)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	expected := "goblin.diffUser{\n    Name: \"ann\",\n    Tags: []string{\n        \"a\",\n    },\n    admin: false,\n}\n"
+	if string(data) != expected {
+		t.Fatalf("Failed: snapshot %q", data)
+	}
+
+	verifier = AssertionVerifier{ShouldPass: true}
+	a = Assertion{src: user, fail: verifier.FailFunc}
+	a.matchSnapshot("user", nil)
+	verifier.Verify(t)
+
+	user.Tags = append(user.Tags, "b")
+	verifier = AssertionVerifier{ShouldPass: false}
+	a = Assertion{src: user, fail: verifier.FailFunc}
+	a.matchSnapshot("user", []interface{}{"tags"})
+	verifier.VerifyMessage(t, `"user" does not match its snapshot `+path+", tags\n"+
+		"--- expected\n"+
+		"+++ actual\n"+
+		" goblin.diffUser{\n"+
+		"     Name: \"ann\",\n"+
+		"     Tags: []string{\n"+
+		"         \"a\",\n"+
+		"+        \"b\",\n"+
+		"     },\n"+
+		"     admin: false,\n"+
+		" }")
+}
+
+func TestSnapshotStrings(t *testing.T) {
+	defer func(dir string) { snapshotDir = dir }(snapshotDir)
+	snapshotDir = t.TempDir()
+	if err := os.WriteFile(filepath.Join(snapshotDir, "page.snap"), []byte("<h1>\r\n</h1>\r\n"), 0o644); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: []byte("<h1>\n</h1>"), fail: verifier.FailFunc}
+	a.matchSnapshot("page", nil)
+	verifier.Verify(t)
+}
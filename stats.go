@@ -0,0 +1,105 @@
+package goblin
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// Stats summarizes the durations and allocations of the samples taken with
+// Sample. Assert against them instead of timing a single, noisy run:
+//
+//	stats := g.Sample(100, 10, func() { parse(input) })
+//	goblin.AssertOrdered(g, stats.Median).IsLessThan(time.Millisecond)
+type Stats struct {
+	Samples    []time.Duration // Duration of each sample, shortest first
+	Min        time.Duration
+	Mean       time.Duration
+	Median     time.Duration
+	P95        time.Duration
+	Max        time.Duration
+	Allocs     uint64 // Heap objects allocated per sample, on average
+	AllocBytes uint64 // Bytes allocated per sample, on average
+}
+
+// Percentile returns the duration at or under which p percent of the samples
+// took, using the nearest rank.
+func (s *Stats) Percentile(p float64) time.Duration {
+	if len(s.Samples) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(s.Samples)))) - 1
+	if rank < 0 {
+		rank = 0
+	} else if rank >= len(s.Samples) {
+		rank = len(s.Samples) - 1
+	}
+	return s.Samples[rank]
+}
+
+func (s *Stats) String() string {
+	return fmt.Sprintf("%d samples: min %s, mean %s, median %s, p95 %s, max %s, %d allocs (%d B) per sample",
+		len(s.Samples), s.Min, s.Mean, s.Median, s.P95, s.Max, s.Allocs, s.AllocBytes)
+}
+
+// Sample runs f warmups times without measuring it, then samples more times,
+// timing each run. The allocations are measured across all samples, like in
+// benchmarks. The summary is written to the spec's output, shown on failure
+// and with go test -v. The spec fails unless it takes at least one sample.
+func (g *G) Sample(samples, warmups int, f func()) *Stats {
+	if samples < 1 || warmups < 0 {
+		g.Fail(fmt.Sprintf("Sample needs at least one sample and no negative warmups, got %d samples and %d warmups", samples, warmups))
+		// Failing only returns once the spec timed out
+		return &Stats{}
+	}
+	for i := 0; i < warmups; i++ {
+		f()
+	}
+
+	stats := sample(samples, func(int) time.Duration {
+		start := time.Now()
+		f()
+		return time.Since(start)
+	})
+	fmt.Fprintln(g.Writer(), stats)
+	return stats
+}
+
+// sample calls run samples times with the index of the sample, which returns
+// how long it took, and summarizes the samples
+func sample(samples int, run func(i int) time.Duration) *Stats {
+	durations := make([]time.Duration, samples)
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := range durations {
+		durations[i] = run(i)
+	}
+	runtime.ReadMemStats(&after)
+
+	stats := newStats(durations)
+	if samples > 0 {
+		stats.Allocs = (after.Mallocs - before.Mallocs) / uint64(samples)
+		stats.AllocBytes = (after.TotalAlloc - before.TotalAlloc) / uint64(samples)
+	}
+	return stats
+}
+
+// newStats summarizes the given durations, sorting them in place
+func newStats(durations []time.Duration) *Stats {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	stats := &Stats{Samples: durations}
+	if len(durations) > 0 {
+		stats.Min = durations[0]
+		stats.Max = durations[len(durations)-1]
+		var total time.Duration
+		for _, d := range durations {
+			total += d
+		}
+		stats.Mean = total / time.Duration(len(durations))
+		stats.Median = stats.Percentile(50)
+		stats.P95 = stats.Percentile(95)
+	}
+	return stats
+}
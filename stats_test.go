@@ -0,0 +1,83 @@
+package goblin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	durations := []time.Duration{}
+	for i := 20; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+	stats := newStats(durations)
+
+	if stats.Min != time.Millisecond || stats.Max != 20*time.Millisecond ||
+		stats.Mean != 10500*time.Microsecond || stats.Median != 10*time.Millisecond || stats.P95 != 19*time.Millisecond {
+		t.Fatalf("Failed: stats %v", stats)
+	}
+	if empty := newStats(nil); empty.Percentile(50) != 0 {
+		t.Fatalf("Failed: empty stats %v", empty)
+	}
+}
+
+func TestSample(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var retained [][]byte
+	calls := 0
+	var stats *Stats
+	g.Describe("Sampling", func() {
+		g.It("Should sample", func() {
+			stats = g.Sample(10, 3, func() {
+				calls++
+				retained = append(retained, make([]byte, 1024))
+			})
+			AssertOrdered(g, stats.Median).IsLessThan(time.Second)
+		}, Timeout(time.Second))
+	})
+
+	if fakeTest.Failed() || calls != 13 || len(stats.Samples) != 10 {
+		t.Fatalf("Failed: %d calls, stats %v", calls, stats)
+	}
+	if stats.Min > stats.Median || stats.Median > stats.P95 || stats.P95 > stats.Max {
+		t.Fatalf("Failed: unordered stats %v", stats)
+	}
+	if stats.Allocs < 1 || stats.AllocBytes < 1024 {
+		t.Fatalf("Failed: allocations %v", stats)
+	}
+}
+
+func TestSampleInvalid(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	calls := 0
+	g.Describe("Sampling", func() {
+		g.It("Should need a sample", func() {
+			g.Sample(-1, 0, func() { calls++ })
+		})
+		g.It("Should need no negative warmups", func() {
+			g.Sample(1, -1, func() { calls++ })
+		})
+		g.Measure("Should need a sample to measure", -1, func(b *B) { calls++ })
+	})
+
+	if calls != 0 || len(reporter.fails) != 3 {
+		t.Fatalf("Failed: %d calls, fails %v", calls, reporter.fails)
+	}
+	expected := "Sample needs at least one sample and no negative warmups, got -1 samples and 0 warmups"
+	if reporter.captured[0].Message != expected {
+		t.Fatalf("Failed: message %q", reporter.captured[0].Message)
+	}
+	if reporter.captured[2].Message != "Measure needs at least one sample, got -1" {
+		t.Fatalf("Failed: message %q", reporter.captured[2].Message)
+	}
+}
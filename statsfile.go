@@ -0,0 +1,63 @@
+package goblin
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// csvFileMu serializes the rows appended to the -goblin.stats-file
+var csvFileMu sync.Mutex
+
+// statsHeader is the first row of a new stats file
+var statsHeader = []string{"timestamp", "id", "status", "duration_seconds", "retries"}
+
+// recordStats appends a row for a spec which ran to the -goblin.stats-file, if
+// there is one, reporting errors without failing the spec.
+func (it *It) recordStats(start time.Time, status string, duration time.Duration, retries int) {
+	if *statsFile == "" {
+		return
+	}
+	row := []string{
+		start.UTC().Format(time.RFC3339Nano),
+		it.id(),
+		status,
+		strconv.FormatFloat(duration.Seconds(), 'f', -1, 64),
+		strconv.Itoa(retries),
+	}
+	if err := appendCSV(*statsFile, statsHeader, row); err != nil {
+		fmt.Printf("goblin: could not write stats: %v\n", err)
+	}
+}
+
+// appendCSV appends row to the CSV file at path, starting it with header if
+// it's new.
+func appendCSV(path string, header, row []string) error {
+	csvFileMu.Lock()
+	defer csvFileMu.Unlock()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	if info.Size() == 0 {
+		w.Write(header)
+	}
+	w.Write(row)
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
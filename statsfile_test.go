@@ -0,0 +1,64 @@
+package goblin
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStatsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.csv")
+	*statsFile = path
+	defer func() {
+		*statsFile = ""
+	}()
+
+	for run := 0; run < 2; run++ {
+		fakeTest := testing.T{}
+		g := Goblin(&fakeTest)
+		g.SetReporter(Reporter(&FakeReporter{}))
+
+		attempts := 0
+		g.Describe("Stats", func() {
+			g.It("Should pass", func() {})
+			g.It("Should pass eventually", func() {
+				attempts++
+				if attempts < 2 {
+					g.Fail("not yet")
+				}
+			}, Retry(2))
+			g.It("Should fail", func() {
+				g.Fail("failed")
+			}, Timeout(time.Second))
+			g.It("Should be pending")
+		})
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+
+	if len(rows) != 7 || !reflect.DeepEqual(rows[0], statsHeader) {
+		t.Fatalf("Failed: rows %v", rows)
+	}
+	var statuses, retries []string
+	for _, row := range rows[1:4] {
+		if _, err := time.Parse(time.RFC3339Nano, row[0]); err != nil || row[1] == "" {
+			t.Fatalf("Failed: row %v", row)
+		}
+		statuses = append(statuses, row[2])
+		retries = append(retries, row[4])
+	}
+	if !reflect.DeepEqual(statuses, []string{"passed", "passed", "failed"}) || !reflect.DeepEqual(retries, []string{"0", "1", "0"}) {
+		t.Fatalf("Failed: statuses %v, retries %v", statuses, retries)
+	}
+}
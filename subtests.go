@@ -0,0 +1,67 @@
+package goblin
+
+import (
+	"strings"
+	"testing"
+)
+
+// runSpec runs a spec as a subtest of the Go test running the suite, named
+// after the spec's path, so go test -run, -v and -json see each spec on its
+// own. Specs which don't run are reported as skipped subtests. Suites run with
+// a testing.T which wasn't created by go test, such as in goblin's own tests,
+// run their specs directly.
+func (g *G) runSpec(r Runnable) bool {
+	var path []string
+	switch r := r.(type) {
+	case *It:
+		path = append(r.parent.path(), r.name)
+	case *Xit:
+		path = append(r.parent.path(), r.name)
+	}
+	if path == nil || g.t.Name() == "" {
+		return r.run(g)
+	}
+
+	failed := false
+	g.t.Run(strings.Join(path, "/"), func(t *testing.T) {
+		g.setSpecT(t)
+		defer g.setSpecT(nil)
+		if failed = r.run(g); failed {
+			t.Fail()
+		} else if reason := notRunReason(r); reason != "" {
+			t.Skip(reason)
+		}
+	})
+	return failed
+}
+
+// notRunReason returns why a spec which didn't fail didn't run, if it didn't
+func notRunReason(r Runnable) string {
+	switch r := r.(type) {
+	case *It:
+		if r.h == nil {
+			return "pending"
+		}
+	case *Xit:
+		return "excluded"
+	}
+	return ""
+}
+
+func (g *G) setSpecT(t *testing.T) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.specT = t
+}
+
+// currentT returns the testing.T of the subtest running the current spec, or
+// the one of the Go test running the suite if specs don't run as subtests.
+func (g *G) currentT() *testing.T {
+	g = g.active()
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.specT != nil {
+		return g.specT
+	}
+	return g.t
+}
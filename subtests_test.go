@@ -0,0 +1,35 @@
+package goblin
+
+import (
+	"testing"
+)
+
+func TestSubtests(t *testing.T) {
+	g := Goblin(t)
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	var names []string
+	g.Describe("Numbers", func() {
+		g.It("Should run as a subtest", func() {
+			names = append(names, g.currentT().Name())
+		})
+		g.Describe("Nested", func() {
+			g.It("Should run as a nested subtest", func() {
+				names = append(names, g.currentT().Name())
+			})
+		})
+		g.It("Should be skipped as pending")
+		g.Xit("Should be skipped as excluded", func() {})
+	})
+
+	expected := []string{
+		"TestSubtests/Numbers/Should_run_as_a_subtest",
+		"TestSubtests/Numbers/Nested/Should_run_as_a_nested_subtest",
+	}
+	if len(names) != 2 || names[0] != expected[0] || names[1] != expected[1] {
+		t.Fatalf("Failed: names %v", names)
+	}
+	if g.currentT() != t {
+		t.Fatal("Failed: subtest still current")
+	}
+}
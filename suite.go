@@ -0,0 +1,304 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// suiteHooks holds the BeforeSuite and AfterSuite hooks of the test binary,
+// shared by the suites of all its Go tests. Each Go test calling Goblin
+// registers them again, so they are told apart by their function.
+var suiteHooks struct {
+	mu       sync.Mutex // Held while the hooks run, so setup and teardown don't overlap
+	ran      map[uintptr]bool
+	failure  error       // Of the first setup hook failing
+	teardown []suiteHook // In the order they were registered
+	refs     int         // Suites using the hooks, plus one while RunSuite runs the tests
+}
+
+// suiteHook is a BeforeSuite or AfterSuite hook, along with the G it was
+// registered with, which its assertions are made on
+type suiteHook struct {
+	key uintptr // Function registered, the same in every suite registering it
+	g   *G
+	h   func()
+}
+
+func newSuiteHook(g *G, f interface{}, h func()) suiteHook {
+	return suiteHook{key: reflect.ValueOf(f).Pointer(), g: g, h: h}
+}
+
+// BeforeSuite registers a hook which runs once before the first test, for
+// expensive setup such as starting a database container. The hook runs once
+// per test binary: every Go test calling Goblin can register the same
+// function, e.g. from a shared helper, and only the first suite to start runs
+// it. Failing assertions within the hook fail every test depending on it.
+//
+// It should be called before the first Describe of the suite; if the suite has
+// already started, the hook runs immediately unless it already ran.
+func (g *G) BeforeSuite(h func()) {
+	g.addSuiteHooks([]suiteHook{newSuiteHook(g, h, h)}, nil)
+}
+
+// AfterSuite registers a hook which runs once after the last test, to tear
+// down what BeforeSuite set up. Like BeforeSuite, the hook runs once per test
+// binary, once no suite which registered it is running anymore. Go tests run
+// one after the other, so unless they run in parallel, each tears the hooks
+// down after it finishes and the next one sets them up again: call RunSuite
+// from TestMain to keep them until all the tests finish. It only runs if the
+// suite started.
+func (g *G) AfterSuite(h func()) {
+	g.addSuiteHooks(nil, []suiteHook{newSuiteHook(g, h, h)})
+}
+
+// SynchronizedBeforeSuite registers setup which runs once before the first
+// test, like BeforeSuite. node1 performs the expensive setup, such as
+// migrating a database or starting a shared container, and returns data, such
+// as connection details, which is passed to all.
+//
+// Goblin runs the tests within a single process, so node1 and all run one
+// after the other in that process. Splitting the setup this way keeps suites
+// ready for runs where node1 happens once and all happens in every process.
+//
+// It should be called before the first Describe of the suite; if the suite has
+// already started, the setup runs immediately unless it already ran.
+func (g *G) SynchronizedBeforeSuite(node1 func() []byte, all func([]byte)) {
+	h := func() {
+		all(node1())
+	}
+	g.addSuiteHooks([]suiteHook{newSuiteHook(g, node1, h)}, nil)
+}
+
+// RunSuite runs the tests of m, keeping the hooks registered with AfterSuite
+// until all of them finish, instead of running them after each Go test. It
+// returns the exit code of the tests, failing them if a hook failed:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(goblin.RunSuite(m))
+//	}
+func RunSuite(m *testing.M) int {
+	suiteHooks.mu.Lock()
+	suiteHooks.refs++
+	suiteHooks.mu.Unlock()
+
+	code := m.Run()
+	for _, err := range releaseSuiteHooks() {
+		fmt.Printf("goblin: %v\n", err)
+		code = 1
+	}
+	return code
+}
+
+// addSuiteHooks registers BeforeSuite and AfterSuite hooks, running the setup
+// at once if the suite already started
+func (g *G) addSuiteHooks(before, after []suiteHook) {
+	if !g.suiteStarted {
+		g.beforeSuite = append(g.beforeSuite, before...)
+		g.afterSuite = append(g.afterSuite, after...)
+		return
+	}
+	g.useSuiteHooks(before, after)
+}
+
+// useSuiteHooks runs the setup hooks which didn't run yet and registers the
+// teardown hooks, holding a reference to them until the suite ends
+func (g *G) useSuiteHooks(before, after []suiteHook) {
+	if len(before) == 0 && len(after) == 0 {
+		return
+	}
+	suiteHooks.mu.Lock()
+	defer suiteHooks.mu.Unlock()
+	if !g.usesSuiteHooks {
+		g.usesSuiteHooks = true
+		suiteHooks.refs++
+	}
+
+	for _, h := range after {
+		registered := false
+		for _, r := range suiteHooks.teardown {
+			if r.key == h.key {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			suiteHooks.teardown = append(suiteHooks.teardown, h)
+		}
+	}
+	for _, h := range before {
+		if suiteHooks.failure != nil {
+			break
+		}
+		if suiteHooks.ran[h.key] {
+			continue
+		}
+		if suiteHooks.ran == nil {
+			suiteHooks.ran = map[uintptr]bool{}
+		}
+		suiteHooks.ran[h.key] = true
+		suiteHooks.failure = h.run(`"before suite" hook`)
+	}
+}
+
+// suiteFailure returns the failure of the setup hooks the suite uses, if one
+// failed
+func (g *G) suiteFailure() error {
+	if !g.usesSuiteHooks {
+		return nil
+	}
+	suiteHooks.mu.Lock()
+	defer suiteHooks.mu.Unlock()
+	return suiteHooks.failure
+}
+
+// releaseSuiteHooks drops a reference to the suite hooks, running the
+// teardown hooks once none are left, and returning the errors of those
+// failing. The setup hooks then run again for the next suite using them.
+func releaseSuiteHooks() []error {
+	suiteHooks.mu.Lock()
+	defer suiteHooks.mu.Unlock()
+	suiteHooks.refs--
+	if suiteHooks.refs > 0 {
+		return nil
+	}
+	return runSuiteTeardown()
+}
+
+// tearDownSuiteHooks runs the teardown hooks at once, even if suites still use
+// them, for a process about to exit
+func tearDownSuiteHooks() []error {
+	suiteHooks.mu.Lock()
+	defer suiteHooks.mu.Unlock()
+	return runSuiteTeardown()
+}
+
+// runSuiteTeardown runs the teardown hooks with suiteHooks.mu held
+func runSuiteTeardown() (errs []error) {
+	for _, h := range suiteHooks.teardown {
+		if err := h.run(`"after suite" hook`); err != nil {
+			errs = append(errs, fmt.Errorf("\"after suite\" hook failed: %v", err))
+		}
+	}
+	suiteHooks.ran, suiteHooks.failure, suiteHooks.teardown = nil, nil, nil
+	return errs
+}
+
+// run runs the hook on the G it was registered with, so that its assertions
+// fail the hook
+func (h suiteHook) run(name string) error {
+	defer timeline.begin(h.g.Shard(), "hook", name)()
+	return h.g.runFailableHook(&hookFailure{}, func() error {
+		h.h()
+		return nil
+	})
+}
+
+// failSuite reports r, and every spec nested in it, as failed by the setup
+// hook failing with err, without running anything
+func failSuite(g *G, r Runnable, err error) {
+	switch r := r.(type) {
+	case *Describe:
+		if !r.hasTests {
+			return
+		}
+		g.reporter.BeginDescribe(r.name)
+		for _, child := range r.children {
+			failSuite(g, child, err)
+		}
+		g.reporter.EndDescribe()
+	case *It:
+		if r.h == nil {
+			g.reporter.ItIsPending(r.name)
+			return
+		}
+		failure := &Failure{
+			ID:       r.id(),
+			Message:  fmt.Sprintf("\"before suite\" hook failed: %v", err),
+			Stack:    err.(*hookFailure).stack,
+			TestName: r.parent.name + " " + r.name,
+		}
+		failure.locate()
+		failure.FuncName = err.(*hookFailure).function
+		g.reporter.ItFailed(r.name)
+		g.reporter.Failure(failure)
+	default:
+		r.run(g)
+	}
+}
+
+// BeforeShard registers a hook run once in each shard of the suite before the
+// shard runs its first test. Each worker running specs in parallel is a shard,
+// and the hook receives its number, starting at 1, so it can set up per shard
+// resources like distinct database schemas or ports, which specs find with
+// Shard. Specs which don't run in parallel run in shard 1.
+func (g *G) BeforeShard(h func(shard int)) {
+	g.beforeShard = append(g.beforeShard, h)
+}
+
+// AfterShard registers a hook run once for each shard which was started, when
+// the test running the suite finishes.
+func (g *G) AfterShard(h func(shard int)) {
+	g.afterShard = append(g.afterShard, h)
+}
+
+// Shard returns the number of the shard running the calling spec, starting
+// at 1.
+func (g *G) Shard() int {
+	if shard := g.active().shard; shard != 0 {
+		return shard
+	}
+	return 1
+}
+
+// startSuite runs the suite setup the first time it is called, returning the
+// failure of the setup hooks, if one failed
+func (g *G) startSuite() error {
+	if !g.suiteStarted {
+		g.suiteStarted = true
+		g.t.Cleanup(g.endSuite)
+
+		g.useSuiteHooks(g.beforeSuite, g.afterSuite)
+		if g.suiteFailure() == nil {
+			g.startShard(1)
+		}
+	}
+	return g.suiteFailure()
+}
+
+// startShard runs the shard setup the first time it is called for shard
+func (g *G) startShard(shard int) {
+	for _, s := range g.shards {
+		if s == shard {
+			return
+		}
+	}
+	g.shards = append(g.shards, shard)
+
+	for _, h := range g.beforeShard {
+		h(shard)
+	}
+}
+
+// endSuite runs the suite teardown once the test running the suite finishes
+func (g *G) endSuite() {
+	if g.suiteEnded {
+		return
+	}
+	g.suiteEnded = true
+
+	for _, shard := range g.shards {
+		for _, h := range g.afterShard {
+			h(shard)
+		}
+	}
+	if g.usesSuiteHooks {
+		for _, err := range releaseSuiteHooks() {
+			g.t.Error(err)
+		}
+	}
+	if r, ok := g.reporter.(SuiteReporter); ok {
+		r.SuiteDone()
+	}
+}
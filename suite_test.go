@@ -0,0 +1,284 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSynchronizedBeforeSuite(t *testing.T) {
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+
+	var ran []string
+	g.SynchronizedBeforeSuite(func() []byte {
+		ran = append(ran, "node1")
+		return []byte("postgres://localhost")
+	}, func(data []byte) {
+		ran = append(ran, "all "+string(data))
+	})
+
+	if len(ran) != 0 {
+		t.Fatalf("Failed: ran before the suite started %v", ran)
+	}
+
+	g.Describe("First", func() {
+		g.It("Should have run the setup", func() {
+			ran = append(ran, "It")
+		})
+	})
+
+	g.Describe("Second", func() {
+		g.It("Should not run the setup again", func() {
+			ran = append(ran, "It")
+		})
+	})
+
+	g.endSuite()
+
+	expected := []string{"node1", "all postgres://localhost", "It", "It"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+}
+
+func TestShardHooks(t *testing.T) {
+	var ran []string
+	t.Run("Suite", func(t *testing.T) {
+		g := Goblin(t)
+		g.SetReporter(Reporter(&FakeReporter{}))
+
+		g.BeforeShard(func(shard int) {
+			ran = append(ran, fmt.Sprintf("BeforeShard %d", shard))
+		})
+		g.AfterShard(func(shard int) {
+			ran = append(ran, fmt.Sprintf("AfterShard %d", shard))
+		})
+
+		g.Describe("First", func() {
+			g.It("Should have set up the shard", func() {
+				ran = append(ran, "It")
+			})
+		})
+
+		g.Describe("Second", func() {
+			g.It("Should not set up the shard again", func() {
+				ran = append(ran, "It")
+			})
+		})
+
+		if !reflect.DeepEqual(ran, []string{"BeforeShard 1", "It", "It"}) {
+			t.Fatalf("Failed: ran %v", ran)
+		}
+	})
+
+	expected := []string{"BeforeShard 1", "It", "It", "AfterShard 1"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+}
+
+func TestShardHooksParallel(t *testing.T) {
+	var mu sync.Mutex
+	var ran []string
+	shards := map[int]int{}
+	t.Run("Suite", func(t *testing.T) {
+		g := Goblin(t, WithParallel(2))
+		g.SetReporter(Reporter(&FakeReporter{}))
+
+		g.BeforeShard(func(shard int) {
+			ran = append(ran, fmt.Sprintf("BeforeShard %d", shard))
+		})
+		g.AfterShard(func(shard int) {
+			ran = append(ran, fmt.Sprintf("AfterShard %d", shard))
+		})
+
+		g.Describe("Numbers", func() {
+			for i := 0; i < 4; i++ {
+				g.It(fmt.Sprintf("Should run in a shard %d", i), func() {
+					time.Sleep(20 * time.Millisecond)
+					mu.Lock()
+					defer mu.Unlock()
+					shards[g.Shard()]++
+				}, Timeout(time.Second))
+			}
+		})
+	})
+
+	expected := []string{"BeforeShard 1", "BeforeShard 2", "AfterShard 1", "AfterShard 2"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	if shards[1] == 0 || shards[2] == 0 || shards[1]+shards[2] != 4 {
+		t.Fatalf("Failed: shards %v", shards)
+	}
+}
+
+func TestSuiteHooks(t *testing.T) {
+	var ran []string
+	t.Run("Suite", func(t *testing.T) {
+		g := Goblin(t)
+		g.SetReporter(Reporter(&FakeReporter{}))
+
+		g.BeforeSuite(func() {
+			ran = append(ran, "BeforeSuite")
+		})
+		g.AfterSuite(func() {
+			ran = append(ran, "AfterSuite")
+		})
+		g.AfterShard(func(shard int) {
+			ran = append(ran, "AfterShard")
+		})
+
+		g.Describe("First", func() {
+			g.It("Should have run the setup", func() {
+				ran = append(ran, "It")
+			})
+		})
+
+		g.Describe("Second", func() {
+			g.It("Should not run the setup again", func() {
+				ran = append(ran, "It")
+			})
+		})
+
+		if !reflect.DeepEqual(ran, []string{"BeforeSuite", "It", "It"}) {
+			t.Fatalf("Failed: ran %v", ran)
+		}
+	})
+
+	expected := []string{"BeforeSuite", "It", "It", "AfterShard", "AfterSuite"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+}
+
+func TestSuiteHooksOncePerBinary(t *testing.T) {
+	var mu sync.Mutex
+	var ran []string
+	record := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, s)
+	}
+	setup := func(g *G) {
+		g.BeforeSuite(func() {
+			record("BeforeSuite")
+		})
+		g.AfterSuite(func() {
+			record("AfterSuite")
+		})
+	}
+
+	suite := func(name string, h func()) {
+		t.Run(name, func(t *testing.T) {
+			g := Goblin(t)
+			g.SetReporter(Reporter(&FakeReporter{}))
+			setup(g)
+
+			g.Describe(name, func() {
+				g.It("Should share the setup", func() {
+					record("It")
+					h()
+				}, Timeout(time.Second))
+			})
+		})
+	}
+	// The second suite runs while the first one is running its spec
+	suite("First", func() {
+		suite("Second", func() {})
+		if !reflect.DeepEqual(ran, []string{"BeforeSuite", "It", "It"}) {
+			t.Errorf("Failed: ran %v", ran)
+		}
+	})
+
+	expected := []string{"BeforeSuite", "It", "It", "AfterSuite"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+}
+
+func TestSuiteHooksKeptUntilReleased(t *testing.T) {
+	var ran []string
+	setup := func(g *G) {
+		g.BeforeSuite(func() {
+			ran = append(ran, "BeforeSuite")
+		})
+		g.AfterSuite(func() {
+			ran = append(ran, "AfterSuite")
+		})
+	}
+
+	// Holding a reference like RunSuite does
+	suiteHooks.mu.Lock()
+	suiteHooks.refs++
+	suiteHooks.mu.Unlock()
+	for _, name := range []string{"First", "Second"} {
+		t.Run(name, func(t *testing.T) {
+			g := Goblin(t)
+			g.SetReporter(Reporter(&FakeReporter{}))
+			setup(g)
+
+			g.Describe("Suite", func() {
+				g.It("Should share the setup", func() {
+					ran = append(ran, "It")
+				})
+			})
+		})
+	}
+
+	if !reflect.DeepEqual(ran, []string{"BeforeSuite", "It", "It"}) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+	if errs := releaseSuiteHooks(); len(errs) != 0 {
+		t.Fatalf("Failed: %v", errs)
+	}
+	expected := []string{"BeforeSuite", "It", "It", "AfterSuite"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("Failed: ran %v", ran)
+	}
+}
+
+func TestBeforeSuiteFailure(t *testing.T) {
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	reporter := outputReporter{}
+	g.SetReporter(Reporter(&reporter))
+
+	tornDown := false
+	g.BeforeSuite(func() {
+		g.Assert(false).IsTrue("database is up")
+	})
+	g.AfterSuite(func() {
+		tornDown = true
+	})
+
+	ran := false
+	g.Describe("First", func() {
+		g.It("Should not run", func() {
+			ran = true
+		})
+	})
+	g.Describe("Second", func() {
+		g.It("Should not run either", func() {
+			ran = true
+		})
+	})
+	g.endSuite()
+
+	if ran || !fakeTest.Failed() {
+		t.Fatalf("Failed: specs ran after the setup failed")
+	}
+	if len(reporter.fails) != 2 || len(reporter.captured) != 2 {
+		t.Fatalf("Failed: failures reported %v", reporter.fails)
+	}
+	failure := reporter.captured[1]
+	if failure.TestName != "Second Should not run either" || failure.Message != `"before suite" hook failed: false expected false to be truthy, database is up` {
+		t.Fatalf("Failed: reported %q: %q", failure.TestName, failure.Message)
+	}
+	if !tornDown {
+		t.Fatalf("Failed: the suite wasn't torn down")
+	}
+}
@@ -0,0 +1,163 @@
+package goblin
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// summaryMu guards summaryTotals, which accumulates the results of every Go
+// test of the package so they all end up in the -goblin.summary file
+var summaryMu sync.Mutex
+var summaryTotals = &runSummary{Failures: []summaryFailure{}}
+
+// runSummary is the document written to the -goblin.summary file
+type runSummary struct {
+	Total            int               `json:"total"`
+	Passed           int               `json:"passed"`
+	Failed           int               `json:"failed"`
+	Pending          int               `json:"pending"`
+	Excluded         int               `json:"excluded"`
+	Skipped          int               `json:"skipped"`
+	ExpectedFailures int               `json:"expected_failures"`
+	Duration         float64           `json:"duration_seconds"`
+	Seed             *int64            `json:"seed,omitempty"` // Randomized the order of the specs with
+	Flags            map[string]string `json:"flags"`          // Set on the command line
+	Failures         []summaryFailure  `json:"failures"`
+}
+
+type summaryFailure struct {
+	Test       string           `json:"test"` // Go test running the suite
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Message    string           `json:"message"`
+	Stack      []string         `json:"stack"`
+	Output     string           `json:"output,omitempty"`
+	Additional []summaryFailure `json:"additional,omitempty"`
+}
+
+// SummaryReporter writes a JSON summary of the run once it ends, with the
+// number of specs by outcome, the duration of the run, its failures and the
+// flags it ran with, for CI scripts to consume. The results of every Go test
+// running a suite are added up, and the file is rewritten as each suite ends.
+// Enabled with -goblin.summary, alongside the usual output.
+type SummaryReporter struct {
+	path     string
+	test     string
+	summary  runSummary
+	seed     *int64
+	start    time.Time
+	failures []summaryFailure
+}
+
+// NewSummaryReporter creates a SummaryReporter writing to the file at path,
+// for the suite run by the Go test named test.
+func NewSummaryReporter(path, test string) *SummaryReporter {
+	return &SummaryReporter{path: path, test: test}
+}
+
+func (r *SummaryReporter) BeginDescribe(name string) {}
+
+func (r *SummaryReporter) EndDescribe() {}
+
+func (r *SummaryReporter) Begin() {
+	r.start = time.Now()
+}
+
+func (r *SummaryReporter) End() {
+	r.summary.Duration = time.Since(r.start).Seconds()
+	if err := r.write(); err != nil {
+		fmt.Printf("goblin: could not write summary: %v\n", err)
+	}
+}
+
+func (r *SummaryReporter) Failure(failure *Failure) {
+	f := r.failure(failure)
+	for _, additional := range failure.Additional {
+		f.Additional = append(f.Additional, r.failure(additional))
+	}
+	r.failures = append(r.failures, f)
+}
+
+func (r *SummaryReporter) failure(failure *Failure) summaryFailure {
+	return summaryFailure{
+		Test:    r.test,
+		ID:      failure.ID,
+		Name:    failure.TestName,
+		Message: failure.Message,
+		Stack:   append([]string{}, failure.Stack...),
+		Output:  failure.Output,
+	}
+}
+
+func (r *SummaryReporter) ItTook(duration time.Duration) {}
+
+func (r *SummaryReporter) ItFailed(name string) {
+	r.summary.Total++
+	r.summary.Failed++
+}
+
+func (r *SummaryReporter) ItPassed(name string) {
+	r.summary.Total++
+	r.summary.Passed++
+}
+
+func (r *SummaryReporter) ItIsPending(name string) {
+	r.summary.Total++
+	r.summary.Pending++
+}
+
+func (r *SummaryReporter) ItIsExcluded(name string) {
+	r.summary.Total++
+	r.summary.Excluded++
+}
+
+func (r *SummaryReporter) ItSkipped(name, reason string) {
+	r.summary.Total++
+	r.summary.Skipped++
+}
+
+func (r *SummaryReporter) ItFailedAsExpected(name, reason string) {
+	r.summary.Total++
+	r.summary.ExpectedFailures++
+}
+
+func (r *SummaryReporter) Randomized(seed int64) {
+	r.seed = &seed
+}
+
+// write adds the results since the last write to those of the other Go tests
+// of the package, then rewrites the file with the totals. The counts of the
+// reporter are reset, so a Go test running several top-level blocks doesn't
+// count its specs twice.
+func (r *SummaryReporter) write() error {
+	summaryMu.Lock()
+	defer summaryMu.Unlock()
+	totals := summaryTotals
+	totals.Total += r.summary.Total
+	totals.Passed += r.summary.Passed
+	totals.Failed += r.summary.Failed
+	totals.Pending += r.summary.Pending
+	totals.Excluded += r.summary.Excluded
+	totals.Skipped += r.summary.Skipped
+	totals.ExpectedFailures += r.summary.ExpectedFailures
+	totals.Duration += r.summary.Duration
+	totals.Failures = append(totals.Failures, r.failures...)
+	r.summary, r.failures = runSummary{}, nil
+	if r.seed != nil {
+		totals.Seed = r.seed
+	}
+	totals.Flags = map[string]string{}
+	flag.Visit(func(f *flag.Flag) {
+		totals.Flags[f.Name] = f.Value.String()
+	})
+
+	data, err := json.MarshalIndent(totals, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0644)
+}
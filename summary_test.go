@@ -0,0 +1,123 @@
+package goblin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSummaryReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	*summaryFile = path
+	defer func() {
+		*summaryFile = ""
+		summaryTotals = &runSummary{Failures: []summaryFailure{}}
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	if m, ok := g.reporter.(multiReporter); !ok || len(m) != 2 {
+		t.Fatalf("Failed: reporter %T", g.reporter)
+	}
+	// The suites below add their own summary reporters
+	*summaryFile = ""
+
+	for _, test := range []string{"TestFirst", "TestSecond"} {
+		fakeTest := testing.T{}
+		g := Goblin(&fakeTest)
+		g.SetReporter(multiReporter{&FakeReporter{}, NewSummaryReporter(path, test)})
+		g.Describe("Numbers", func() {
+			g.It("Should add", func() {}, Timeout(time.Second))
+			g.It("Should fail", func() {
+				g.Fail("failed")
+			}, Timeout(time.Second))
+			g.It("Should be pending")
+			g.It("Should fail as expected", func() {
+				g.Fail("known")
+			}, ExpectedToFail("#1"), Timeout(time.Second))
+		})
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if summary.Total != 8 || summary.Passed != 2 || summary.Failed != 2 || summary.Pending != 2 ||
+		summary.ExpectedFailures != 2 || summary.Duration <= 0 {
+		t.Fatalf("Failed: summary %+v", summary)
+	}
+	if len(summary.Failures) != 2 || summary.Failures[1].Test != "TestSecond" ||
+		summary.Failures[1].Name != "Numbers Should fail" || summary.Failures[1].Message != "failed" ||
+		len(summary.Failures[1].Stack) == 0 {
+		t.Fatalf("Failed: failures %+v", summary.Failures)
+	}
+	if summary.Flags["goblin.timeout"] != "10ms" {
+		t.Fatalf("Failed: flags %v", summary.Flags)
+	}
+}
+
+func TestSummaryReporterSeveralDescribes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	defer func() {
+		summaryTotals = &runSummary{Failures: []summaryFailure{}}
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(multiReporter{&FakeReporter{}, NewSummaryReporter(path, "TestSeveral")})
+	g.Describe("Numbers", func() {
+		g.It("Should fail", func() {
+			g.Fail("failed")
+		}, Timeout(time.Second))
+	})
+	g.Describe("Strings", func() {
+		g.It("Should concatenate", func() {}, Timeout(time.Second))
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if summary.Total != 2 || summary.Passed != 1 || summary.Failed != 1 || len(summary.Failures) != 1 {
+		t.Fatalf("Failed: summary %+v", summary)
+	}
+}
+
+func TestSummaryReporterWithSetReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	*summaryFile = path
+	defer func() {
+		*summaryFile = ""
+		summaryTotals = &runSummary{Failures: []summaryFailure{}}
+	}()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	var summary runSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if summary.Total != 1 || summary.Passed != 1 || len(reporter.passes) != 1 {
+		t.Fatalf("Failed: summary %+v, passes %v", summary, reporter.passes)
+	}
+}
@@ -0,0 +1,275 @@
+package goblin
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// maxEntryNameLength is the length generated entry names are truncated to
+const maxEntryNameLength = 64
+
+// TableEntry is a single row of a table declared with DescribeTable. Each
+// entry is expanded into its own It.
+type TableEntry struct {
+	Description string
+	Args        []interface{}
+	Decorators  []Decorator
+	focused     bool
+	excluded    bool
+}
+
+// Entry creates a table entry with the given description. The args are passed
+// to the table body when the entry's It runs, except for any Decorators (such
+// as Label or Timeout) which are applied to the It instead. If description is
+// empty, a name is generated from the args.
+func Entry(description string, args ...interface{}) TableEntry {
+	args, decorators := splitDecorators(args)
+	return TableEntry{Description: description, Args: args, Decorators: decorators}
+}
+
+// FEntry creates a focused table entry. When a suite contains focused entries,
+// only those are run and everything else is reported as excluded.
+func FEntry(description string, args ...interface{}) TableEntry {
+	entry := Entry(description, args...)
+	entry.focused = true
+	return entry
+}
+
+// XEntry creates a table entry which is excluded from running.
+func XEntry(description string, args ...interface{}) TableEntry {
+	entry := Entry(description, args...)
+	entry.excluded = true
+	return entry
+}
+
+// Entry is the same as the package level Entry.
+func (g *G) Entry(description string, args ...interface{}) TableEntry {
+	return Entry(description, args...)
+}
+
+// FEntry is the same as the package level FEntry.
+func (g *G) FEntry(description string, args ...interface{}) TableEntry {
+	return FEntry(description, args...)
+}
+
+// XEntry is the same as the package level XEntry.
+func (g *G) XEntry(description string, args ...interface{}) TableEntry {
+	return XEntry(description, args...)
+}
+
+// DescribeTable declares a Describe block containing one It per entry. Each It
+// calls body, which must be a function, with the arguments of its entry. An
+// entry whose arguments don't fit the body fails on its own rather than
+// aborting the whole table.
+func (g *G) DescribeTable(name string, body interface{}, entries ...TableEntry) {
+	g.Describe(name, func() {
+		names := entryNames{}
+		for _, entry := range entries {
+			entry := entry
+			name := names.name(entry.Description, entry.Args...)
+			h := func() {
+				callTableBody(g, body, entry.Args)
+			}
+			if entry.excluded {
+				g.Xit(name, h)
+				continue
+			}
+			g.it(name, []interface{}{h}, entry.Decorators, entry.focused)
+		}
+	})
+}
+
+// callTableBody calls the table body with args, failing the current It if the
+// body can't be called with them.
+func callTableBody(g *G, body interface{}, args []interface{}) {
+	in, err := tableArgs(body, args)
+	if err != nil {
+		g.Fail(err)
+		return
+	}
+	reflect.ValueOf(body).Call(in)
+}
+
+// tableArgs converts args into values suitable for calling body, returning an
+// error describing the mismatch if that isn't possible.
+func tableArgs(body interface{}, args []interface{}) ([]reflect.Value, error) {
+	fn := reflect.ValueOf(body)
+	if fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("table body must be a function, got %T", body)
+	}
+
+	t := fn.Type()
+	if t.IsVariadic() {
+		if len(args) < t.NumIn()-1 {
+			return nil, fmt.Errorf("table body expects at least %d arguments, got %d", t.NumIn()-1, len(args))
+		}
+	} else if len(args) != t.NumIn() {
+		return nil, fmt.Errorf("table body expects %d arguments, got %d", t.NumIn(), len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var param reflect.Type
+		if t.IsVariadic() && i >= t.NumIn()-1 {
+			param = t.In(t.NumIn() - 1).Elem()
+		} else {
+			param = t.In(i)
+		}
+
+		if arg == nil {
+			switch param.Kind() {
+			case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+				in[i] = reflect.Zero(param)
+				continue
+			}
+			return nil, fmt.Errorf("table argument %d is nil, which can't be used as %s", i, param)
+		}
+
+		v := reflect.ValueOf(arg)
+		if !v.Type().AssignableTo(param) {
+			converted, ok := convertNumber(v, param)
+			if !ok {
+				return nil, fmt.Errorf("table argument %d is %s, which can't be used as %s", i, v.Type(), param)
+			}
+			v = converted
+		}
+		in[i] = v
+	}
+	return in, nil
+}
+
+// Table is a table of typed entries. Unlike DescribeTable, the body receives
+// the entry's value directly, so entries are checked at compile time:
+//
+//	goblin.NewTable[Case](g, "Addition").
+//		Entry("one and one", Case{1, 1, 2}).
+//		Entry("two and two", Case{2, 2, 4}).
+//		Run(func(c Case) {
+//			g.Assert(c.A + c.B).Equal(c.Sum)
+//		})
+type Table[T any] struct {
+	g       *G
+	name    string
+	entries []TypedEntry[T]
+}
+
+// TypedEntry is a single row of a Table.
+type TypedEntry[T any] struct {
+	Description string
+	Value       T
+	Decorators  []Decorator
+	focused     bool
+	excluded    bool
+}
+
+// NewTable creates an empty Table which is declared as a Describe block named
+// name once Run is called.
+func NewTable[T any](g *G, name string) *Table[T] {
+	return &Table[T]{g: g, name: name}
+}
+
+// Entry adds an entry to the table.
+func (t *Table[T]) Entry(description string, value T, decorators ...Decorator) *Table[T] {
+	t.entries = append(t.entries, TypedEntry[T]{Description: description, Value: value, Decorators: decorators})
+	return t
+}
+
+// FEntry adds a focused entry to the table.
+func (t *Table[T]) FEntry(description string, value T, decorators ...Decorator) *Table[T] {
+	t.entries = append(t.entries, TypedEntry[T]{Description: description, Value: value, Decorators: decorators, focused: true})
+	return t
+}
+
+// XEntry adds an entry to the table which is excluded from running.
+func (t *Table[T]) XEntry(description string, value T, decorators ...Decorator) *Table[T] {
+	t.entries = append(t.entries, TypedEntry[T]{Description: description, Value: value, Decorators: decorators, excluded: true})
+	return t
+}
+
+// Run declares the table's Describe block with one It per entry, each calling
+// body with the entry's value.
+func (t *Table[T]) Run(body func(T)) {
+	t.g.Describe(t.name, func() {
+		names := entryNames{}
+		for _, entry := range t.entries {
+			entry := entry
+			name := names.name(entry.Description, entry.Value)
+			h := func() {
+				body(entry.Value)
+			}
+			if entry.excluded {
+				t.g.Xit(name, h)
+				continue
+			}
+			t.g.it(name, []interface{}{h}, entry.Decorators, entry.focused)
+		}
+	})
+}
+
+// entryNames tracks the names given to the entries of a table so that entries
+// without a description still get a unique name.
+type entryNames map[string]int
+
+// name returns description, or a name generated from args if description is
+// empty. Generated names are made unique by appending a counter.
+func (n entryNames) name(description string, args ...interface{}) string {
+	if description != "" {
+		return description
+	}
+
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		formatted[i] = fmt.Sprintf("%v", arg)
+	}
+	name := "Entry: " + sanitizeEntryName(strings.Join(formatted, ", "))
+
+	n[name]++
+	if n[name] > 1 {
+		name = fmt.Sprintf("%s #%d", name, n[name])
+	}
+	return name
+}
+
+// sanitizeEntryName collapses whitespace and drops control characters so the
+// name fits on one line, truncating it to maxEntryNameLength.
+func sanitizeEntryName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+
+	runes := []rune(name)
+	if len(runes) > maxEntryNameLength {
+		name = string(runes[:maxEntryNameLength-3]) + "..."
+	}
+	return name
+}
+
+// convertNumber converts the number v to the numeric type t, such as a float64
+// decoded from JSON to an int, as long as its value doesn't change
+func convertNumber(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if !isNumber(v.Kind()) || !isNumber(t.Kind()) {
+		return reflect.Value{}, false
+	}
+	converted := v.Convert(t)
+	if converted.Convert(v.Type()).Interface() != v.Interface() {
+		return reflect.Value{}, false
+	}
+	return converted, true
+}
+
+// isNumber returns whether values of kind k are integers or floats
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
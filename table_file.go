@@ -0,0 +1,114 @@
+package goblin
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DescribeTableFromFile declares a table whose entries are loaded from a CSV or
+// JSON file, or a file in any format registered with RegisterFixtureDecoder,
+// such as YAML. Relative paths are resolved against the package's testdata
+// directory.
+//
+// The first row of a CSV file is a header and is skipped; every other row is
+// passed to body as string arguments. Other files must hold an array: elements
+// which are arrays are passed to body as arguments, any other element is
+// passed as a single argument. Numbers are converted to the numeric types of
+// the parameters of body, as long as they fit. Failures of an entry are
+// attributed to the file and row it was loaded from.
+func (g *G) DescribeTableFromFile(name, path string, body interface{}) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join("testdata", path)
+	}
+
+	entries, err := loadTableFile(g, path)
+	if err != nil {
+		g.Describe(name, func() {
+			g.It("Should load "+path, func() {
+				g.Fail(err)
+			})
+		})
+		return
+	}
+	g.DescribeTable(name, body, entries...)
+}
+
+// loadTableFile reads the entries of a table from path
+func loadTableFile(g *G, path string) ([]TableEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".csv" {
+		return loadTableCSV(g, path, f)
+	}
+
+	fixtureDecoders.mu.RLock()
+	decode := fixtureDecoders.decode[ext]
+	supported := []string{".csv"}
+	for ext := range fixtureDecoders.decode {
+		supported = append(supported, ext)
+	}
+	fixtureDecoders.mu.RUnlock()
+	if decode == nil {
+		sort.Strings(supported)
+		return nil, fmt.Errorf("%s: unsupported table file format, expected %s", path, strings.Join(supported, ", "))
+	}
+	return loadTableDecoded(g, path, f, decode)
+}
+
+func loadTableCSV(g *G, path string, r io.Reader) ([]TableEntry, error) {
+	reader := csv.NewReader(r)
+	var entries []TableEntry
+	for row := 0; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row == 0 {
+			// Skip the header
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		args := make([]interface{}, len(record))
+		for i, field := range record {
+			args[i] = field
+		}
+		args = append(args, dataSource(fmt.Sprintf("%s:%d", path, line)))
+		entries = append(entries, g.Entry("", args...))
+	}
+}
+
+func loadTableDecoded(g *G, path string, r io.Reader, decode func(data []byte, v interface{}) error) ([]TableEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var rows []interface{}
+	if err := decode(data, &rows); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	entries := make([]TableEntry, len(rows))
+	for i, row := range rows {
+		args, ok := row.([]interface{})
+		if !ok {
+			args = []interface{}{row}
+		}
+		args = append(args, dataSource(fmt.Sprintf("%s:row %d", path, i+1)))
+		entries[i] = g.Entry("", args...)
+	}
+	return entries, nil
+}
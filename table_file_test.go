@@ -0,0 +1,110 @@
+package goblin
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type tableFileReporter struct {
+	FakeReporter
+	failureStacks [][]string
+}
+
+func (r *tableFileReporter) Failure(failure *Failure) {
+	r.FakeReporter.Failure(failure)
+	r.failureStacks = append(r.failureStacks, failure.Stack)
+}
+
+func TestDescribeTableFromFile(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := tableFileReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.DescribeTableFromFile("CSV", "addition.csv", func(a, b, sum string) {
+		x, _ := strconv.Atoi(a)
+		y, _ := strconv.Atoi(b)
+		g.Assert(strconv.Itoa(x + y)).Equal(sum)
+	})
+
+	g.DescribeTableFromFile("JSON", "addition.json", func(row interface{}, rest ...interface{}) {
+		if m, ok := row.(map[string]interface{}); ok {
+			g.Assert(m["a"].(float64) + m["b"].(float64)).Equal(m["sum"])
+			return
+		}
+		g.Assert(row.(float64) + rest[0].(float64)).Equal(rest[1])
+	})
+
+	g.DescribeTableFromFile("Missing", "missing.csv", func() {})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Entry: 1, 1, 2", "Entry: 1, 1, 2", "Entry: map[a:2 b:2 sum:4]"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Entry: 2, 2, 5", "Should load testdata/missing.csv"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+	if reporter.failureStacks[0][0] != "testdata/addition.csv:3" {
+		t.Fatalf("Failed: stack %v", reporter.failureStacks[0])
+	}
+}
+
+func TestDescribeTableFromFileNumbers(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.DescribeTableFromFile("Integers", "sums.json", func(a, b, sum int) {
+		g.Assert(a + b).Equal(sum)
+	})
+	g.DescribeTable("Halves", func(n int) {}, g.Entry("", 1.5))
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Entry: 1, 1, 2", "Entry: 2, 3, 5"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"Entry: 1.5"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestDescribeTableFromFileDecoder(t *testing.T) {
+	defer func() {
+		fixtureDecoders.mu.Lock()
+		delete(fixtureDecoders.decode, ".txt")
+		fixtureDecoders.mu.Unlock()
+	}()
+	// Decodes a row of integers per line
+	RegisterFixtureDecoder(".txt", func(data []byte, v interface{}) error {
+		rows := v.(*[]interface{})
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var row []interface{}
+			for _, field := range strings.Fields(line) {
+				n, err := strconv.Atoi(field)
+				if err != nil {
+					return err
+				}
+				row = append(row, n)
+			}
+			*rows = append(*rows, row)
+		}
+		return nil
+	})
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.DescribeTableFromFile("Text", "sums.txt", func(a, b, sum int) {
+		g.Assert(a + b).Equal(sum)
+	})
+
+	if !reflect.DeepEqual(reporter.passes, []string{"Entry: 1, 1, 2", "Entry: 2, 2, 4"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+}
@@ -0,0 +1,229 @@
+package goblin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDescribeTable(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var sums []int
+	g.DescribeTable("Addition", func(a, b, sum int) {
+		sums = append(sums, sum)
+		g.Assert(a + b).Equal(sum)
+	},
+		g.Entry("one and one", 1, 1, 2),
+		g.Entry("two and two", 2, 2, 4),
+		g.Entry("wrong sum", 2, 2, 5),
+	)
+
+	if !reflect.DeepEqual(sums, []int{2, 4, 5}) {
+		t.Fatalf("Failed: ran entries %v", sums)
+	}
+	if !reflect.DeepEqual(reporter.describes, []string{"Addition"}) {
+		t.Fatalf("Failed: describes %v", reporter.describes)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"one and one", "two and two"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"wrong sum"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestPackageEntries(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.DescribeTable("Addition", func(a, b, sum int) {
+		g.Assert(a + b).Equal(sum)
+	},
+		Entry("one and one", 1, 1, 2),
+		XEntry("not yet", 2, 2, 5),
+		Entry("two and two", 2, 2, 4, Label("even")),
+	)
+
+	if !reflect.DeepEqual(reporter.passes, []string{"one and one", "two and two"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.excluded, []string{"not yet"}) {
+		t.Fatalf("Failed: excluded %v", reporter.excluded)
+	}
+}
+
+func TestDescribeTableArguments(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var joined []string
+	g.DescribeTable("Variadic", func(sep string, parts ...string) {
+		s := ""
+		for i, p := range parts {
+			if i > 0 {
+				s += sep
+			}
+			s += p
+		}
+		joined = append(joined, s)
+	},
+		g.Entry("no parts", ","),
+		g.Entry("some parts", ",", "a", "b"),
+	)
+
+	g.DescribeTable("Mismatched", func(s string, p *int) {},
+		g.Entry("nil pointer", "a", nil),
+		g.Entry("too few", "a"),
+		g.Entry("wrong type", 1, nil),
+	)
+
+	if !reflect.DeepEqual(joined, []string{"", "a,b"}) {
+		t.Fatalf("Failed: joined %v", joined)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"no parts", "some parts", "nil pointer"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"too few", "wrong type"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestTable(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	type addition struct {
+		a, b, sum int
+	}
+
+	var ran []addition
+	NewTable[addition](g, "Addition").
+		Entry("one and one", addition{1, 1, 2}).
+		Entry("wrong sum", addition{2, 2, 5}).
+		Run(func(c addition) {
+			ran = append(ran, c)
+			g.Assert(c.a + c.b).Equal(c.sum)
+		})
+
+	if !reflect.DeepEqual(ran, []addition{{1, 1, 2}, {2, 2, 5}}) {
+		t.Fatalf("Failed: ran entries %v", ran)
+	}
+	if !reflect.DeepEqual(reporter.passes, []string{"one and one"}) {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"wrong sum"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestFocusedAndExcludedEntries(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var ran []int
+	g.Describe("Table", func() {
+		g.It("Unfocused", func() {
+			ran = append(ran, 0)
+		})
+
+		g.DescribeTable("Entries", func(i int) {
+			ran = append(ran, i)
+		},
+			g.Entry("one", 1),
+			g.FEntry("two", 2),
+			g.XEntry("three", 3),
+		)
+
+		NewTable[int](g, "Typed").
+			Entry("four", 4).
+			FEntry("five", 5).
+			XEntry("six", 6).
+			Run(func(i int) {
+				ran = append(ran, i)
+			})
+	})
+
+	if !reflect.DeepEqual(ran, []int{2, 5}) {
+		t.Fatalf("Failed: ran entries %v", ran)
+	}
+	if !reflect.DeepEqual(reporter.excluded, []string{"Unfocused", "one", "three", "four", "six"}) {
+		t.Fatalf("Failed: excluded %v", reporter.excluded)
+	}
+}
+
+func TestEntryDecorators(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var labels [][]string
+	g.DescribeTable("Decorated", func(d time.Duration) {
+		if d == 0 {
+			labels = append(labels, g.currentIt.(*It).labels)
+		}
+		time.Sleep(d)
+	},
+		g.Entry("labeled", time.Duration(0), Label("slow", "network")),
+		g.Entry("unlabeled", time.Duration(0)),
+		g.Entry("slow", 50*time.Millisecond, Timeout(10*time.Millisecond)),
+	)
+
+	if !reflect.DeepEqual(labels, [][]string{{"slow", "network"}, nil}) {
+		t.Fatalf("Failed: labels %v", labels)
+	}
+	if !reflect.DeepEqual(reporter.fails, []string{"slow"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
+
+func TestGeneratedEntryNames(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.DescribeTable("Generated", func(s string, i int) {},
+		g.Entry("", "a", 1),
+		g.Entry("", "a", 1),
+		g.Entry("", "multi\nline\tvalue", 2),
+		g.Entry("", strings.Repeat("x", 100), 3),
+		g.Entry("described", "b", 2),
+	)
+
+	NewTable[int](g, "Typed").
+		Entry("", 7).
+		Run(func(int) {})
+
+	expected := []string{
+		"Entry: a, 1",
+		"Entry: a, 1 #2",
+		"Entry: multi line value, 2",
+		"Entry: " + strings.Repeat("x", 61) + "...",
+		"described",
+		"Entry: 7",
+	}
+	if !reflect.DeepEqual(reporter.passes, expected) {
+		t.Fatalf("Failed: passes %q", reporter.passes)
+	}
+}
@@ -0,0 +1,123 @@
+package goblin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// TAPReporter writes the results in the Test Anything Protocol, version 13,
+// for TAP consumers like prove. Each spec is a test point named after its
+// path, failures carry a YAML diagnostic block, and the plan is written once
+// the Go test running the suite finishes, so the specs of all its top-level
+// blocks form a single stream. Selected with -goblin.format=tap.
+type TAPReporter struct {
+	w         io.Writer
+	describes []string
+	count     int
+	begun     bool // Whether the version was written
+}
+
+// NewTAPReporter creates a TAPReporter writing to w.
+func NewTAPReporter(w io.Writer) *TAPReporter {
+	return &TAPReporter{w: w}
+}
+
+// point writes a test point for the spec name, with a directive if given
+func (r *TAPReporter) point(status, name, directive string) {
+	r.count++
+	description := strings.Join(append(append([]string(nil), r.describes...), name), " ")
+	description = strings.ReplaceAll(description, "#", "\\#")
+	line := fmt.Sprintf("%s %d - %s", status, r.count, description)
+	if directive != "" {
+		line += " # " + directive
+	}
+	fmt.Fprintln(r.w, line)
+}
+
+// yamlString formats s as a YAML scalar
+func yamlString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func (r *TAPReporter) BeginDescribe(name string) {
+	r.describes = append(r.describes, name)
+}
+
+func (r *TAPReporter) EndDescribe() {
+	r.describes = r.describes[:len(r.describes)-1]
+}
+
+func (r *TAPReporter) Begin() {
+	if !r.begun {
+		fmt.Fprintln(r.w, "TAP version 13")
+		r.begun = true
+	}
+}
+
+func (r *TAPReporter) ItMeasured(name string, stats *Stats) {
+	fmt.Fprintf(r.w, "# %s\n", stats)
+}
+
+func (r *TAPReporter) Randomized(seed int64) {
+	fmt.Fprintf(r.w, "# randomized with seed %d\n", seed)
+}
+
+func (r *TAPReporter) End() {}
+
+func (r *TAPReporter) SuiteDone() {
+	fmt.Fprintf(r.w, "1..%d\n", r.count)
+}
+
+func (r *TAPReporter) Failure(failure *Failure) {
+	lines := []string{"  ---", "  message: " + yamlString(failure.Message)}
+	if failure.ID != "" {
+		lines = append(lines, "  id: "+yamlString(failure.ID))
+	}
+	if len(failure.Stack) > 0 {
+		lines = append(lines, "  stack:")
+		for _, entry := range failure.Stack {
+			lines = append(lines, "    - "+yamlString(strings.TrimSpace(entry)))
+		}
+	}
+	if len(failure.Additional) > 0 {
+		lines = append(lines, "  also:")
+		for _, additional := range failure.Additional {
+			lines = append(lines, "    - "+yamlString(additional.Message))
+		}
+	}
+	if failure.Output != "" {
+		lines = append(lines, "  output: "+yamlString(failure.Output))
+	}
+	lines = append(lines, "  ...")
+	fmt.Fprintln(r.w, strings.Join(lines, "\n"))
+}
+
+func (r *TAPReporter) ItTook(duration time.Duration) {}
+
+func (r *TAPReporter) ItFailed(name string) {
+	r.point("not ok", name, "")
+}
+
+func (r *TAPReporter) ItPassed(name string) {
+	r.point("ok", name, "")
+}
+
+func (r *TAPReporter) ItIsPending(name string) {
+	r.point("ok", name, "SKIP pending")
+}
+
+func (r *TAPReporter) ItIsExcluded(name string) {
+	r.point("ok", name, "SKIP excluded")
+}
+
+func (r *TAPReporter) ItSkipped(name, reason string) {
+	r.point("ok", name, "SKIP "+reason)
+}
+
+func (r *TAPReporter) ItFailedAsExpected(name, reason string) {
+	r.point("not ok", name, "TODO expected failure: "+reason)
+}
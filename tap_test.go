@@ -0,0 +1,69 @@
+package goblin
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestTAPReporter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewTAPReporter(&out)))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add #1", func() {}, Timeout(time.Second))
+		g.Describe("Nested", func() {
+			g.It("Should fail", func() {
+				g.Fail("failed: badly")
+			}, Timeout(time.Second))
+		})
+		g.It("Should be pending")
+		g.It("Should fail as expected", func() {
+			g.Fail("bug")
+		}, ExpectedToFail("#123"), Timeout(time.Second))
+	})
+	// Done once the Go test finishes
+	g.endSuite()
+
+	expected := regexp.MustCompile(`^TAP version 13
+ok 1 - Numbers Should add \\#1
+not ok 2 - Numbers Nested Should fail
+  ---
+  message: "failed: badly"
+  id: "[0-9a-f]+"
+  stack:
+    - ".*tap_test.go:\d+.*"
+(    - ".*"
+)*  \.\.\.
+ok 3 - Numbers Should be pending # SKIP pending
+not ok 4 - Numbers Should fail as expected # TODO expected failure: #123
+1\.\.4
+$`)
+	if !expected.MatchString(out.String()) {
+		t.Fatalf("Failed: output\n%s", out.String())
+	}
+}
+
+func TestTAPReporterSeveralDescribes(t *testing.T) {
+	var out bytes.Buffer
+	t.Run("Suite", func(t *testing.T) {
+		g := Goblin(t)
+		g.SetReporter(Reporter(NewTAPReporter(&out)))
+
+		g.Describe("Numbers", func() {
+			g.It("Should add", func() {}, Timeout(time.Second))
+		})
+		g.Describe("Strings", func() {
+			g.It("Should concatenate", func() {}, Timeout(time.Second))
+		})
+	})
+
+	expected := "TAP version 13\nok 1 - Numbers Should add\nok 2 - Strings Should concatenate\n1..2\n"
+	if out.String() != expected {
+		t.Fatalf("Failed: output\n%s", out.String())
+	}
+}
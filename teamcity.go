@@ -0,0 +1,115 @@
+package goblin
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TeamCityReporter writes TeamCity service messages, so TeamCity, and JetBrains
+// IDEs running the tests, show every spec as a test within its Describe
+// blocks. Selected with -goblin.format=teamcity.
+type TeamCityReporter struct {
+	w         io.Writer
+	describes []string
+	running   string // Name of the running spec
+	notRun    bool   // Whether the running spec is a hook which failed without running
+	mu        sync.Mutex
+}
+
+// NewTeamCityReporter creates a TeamCityReporter writing to w.
+func NewTeamCityReporter(w io.Writer) *TeamCityReporter {
+	return &TeamCityReporter{w: w}
+}
+
+// teamCityEscaper escapes the values of service messages
+var teamCityEscaper = strings.NewReplacer(
+	"|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]",
+	"\u0085", "|x", "\u2028", "|l", "\u2029", "|p",
+)
+
+// message writes the service message named name with the given attributes,
+// as pairs of names and values
+func (r *TeamCityReporter) message(name string, attributes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+	b.WriteString("##teamcity[" + name)
+	for i := 0; i+1 < len(attributes); i += 2 {
+		fmt.Fprintf(&b, " %s='%s'", attributes[i], teamCityEscaper.Replace(attributes[i+1]))
+	}
+	b.WriteString("]\n")
+	io.WriteString(r.w, b.String())
+}
+
+// ignore reports a spec which doesn't run
+func (r *TeamCityReporter) ignore(name, reason string) {
+	r.message("testIgnored", "name", name, "message", reason)
+}
+
+func (r *TeamCityReporter) BeginDescribe(name string) {
+	r.describes = append(r.describes, name)
+	r.message("testSuiteStarted", "name", name)
+}
+
+func (r *TeamCityReporter) EndDescribe() {
+	name := r.describes[len(r.describes)-1]
+	r.describes = r.describes[:len(r.describes)-1]
+	r.message("testSuiteFinished", "name", name)
+}
+
+func (r *TeamCityReporter) SpecStarted(report *SpecReport) {
+	r.running = report.Path[len(report.Path)-1]
+	r.message("testStarted", "name", r.running, "locationHint", fmt.Sprintf("file://%s:%d", report.File, report.Line))
+}
+
+func (r *TeamCityReporter) SpecDone(report *SpecReport) {
+	r.message("testFinished", "name", r.running, "duration", fmt.Sprint(report.Duration.Milliseconds()))
+	r.running = ""
+}
+
+func (r *TeamCityReporter) ItFailed(name string) {
+	if r.running == "" {
+		// Failed Before and After hooks are reported without running
+		r.running, r.notRun = name, true
+		r.message("testStarted", "name", name)
+	}
+}
+
+func (r *TeamCityReporter) Failure(failure *Failure) {
+	details := append([]string(nil), failure.Stack...)
+	for _, additional := range failure.Additional {
+		details = append(append(details, "Also: "+formatFailure(additional)), additional.Stack...)
+	}
+	for i, line := range details {
+		details[i] = strings.TrimSpace(line)
+	}
+	r.message("testFailed", "name", r.running, "message", formatFailure(failure), "details", strings.Join(details, "\n"))
+	if failure.Output != "" {
+		r.message("testStdOut", "name", r.running, "out", failure.Output)
+	}
+
+	if r.notRun {
+		r.message("testFinished", "name", r.running)
+		r.running, r.notRun = "", false
+	}
+}
+
+func (r *TeamCityReporter) ItIsPending(name string) {
+	r.ignore(name, "pending")
+}
+
+func (r *TeamCityReporter) ItIsExcluded(name string) {
+	r.ignore(name, "excluded")
+}
+
+func (r *TeamCityReporter) ItSkipped(name, reason string) {
+	r.ignore(name, reason)
+}
+
+func (r *TeamCityReporter) Begin()               {}
+func (r *TeamCityReporter) End()                 {}
+func (r *TeamCityReporter) ItPassed(string)      {}
+func (r *TeamCityReporter) ItTook(time.Duration) {}
@@ -0,0 +1,81 @@
+package goblin
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTeamCityReporter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewTeamCityReporter(&out)))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.Describe("Nested", func() {
+			g.It("Should fail", func() {
+				g.Fail("expected '1'\nto be [2]")
+			}, Timeout(time.Second))
+		})
+		g.It("Should be pending")
+	})
+
+	// Keep the names of the messages and of the tests, dropping the rest
+	attributes := regexp.MustCompile(` (name='[^']*')?.*\]$`)
+	var messages []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		messages = append(messages, attributes.ReplaceAllString(line, " $1]"))
+	}
+
+	expected := []string{
+		"##teamcity[testSuiteStarted name='Numbers']",
+		"##teamcity[testStarted name='Should add']",
+		"##teamcity[testFinished name='Should add']",
+		"##teamcity[testSuiteStarted name='Nested']",
+		"##teamcity[testStarted name='Should fail']",
+		"##teamcity[testFailed name='Should fail']",
+		"##teamcity[testFinished name='Should fail']",
+		"##teamcity[testSuiteFinished name='Nested']",
+		"##teamcity[testIgnored name='Should be pending']",
+		"##teamcity[testSuiteFinished name='Numbers']",
+	}
+	if strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Failed: messages\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "message='expected |'1|'|nto be |[2|]'") {
+		t.Fatalf("Failed: escaping\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "locationHint='file://") || !strings.Contains(out.String(), "teamcity_test.go:") {
+		t.Fatalf("Failed: location\n%s", out.String())
+	}
+}
+
+func TestTeamCityReporterHookFailure(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewTeamCityReporter(&out)))
+
+	g.Describe("Numbers", func() {
+		g.After(func() {
+			g.Fail("could not clean up")
+		})
+		g.It("Should add", func() {}, Timeout(time.Second))
+	})
+
+	for _, message := range []string{
+		`##teamcity[testStarted name='"after all" hook']`,
+		`##teamcity[testFailed name='"after all" hook' message='could not clean up'`,
+		`##teamcity[testFinished name='"after all" hook']`,
+	} {
+		if !strings.Contains(out.String(), message) {
+			t.Fatalf("Failed: %s\n%s", message, out.String())
+		}
+	}
+}
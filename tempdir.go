@@ -0,0 +1,36 @@
+package goblin
+
+import (
+	"fmt"
+	"os"
+)
+
+// TempDir creates a new temporary directory and returns its path. It's
+// removed along with its content once the spec or block being run finishes, as
+// if with Cleanup.
+func (g *G) TempDir() string {
+	dir, err := os.MkdirTemp("", "goblin-")
+	if err != nil {
+		g.Fail(fmt.Sprintf("can't create a temporary directory: %v", err))
+	}
+	g.Cleanup(func() {
+		if err := os.RemoveAll(dir); err != nil {
+			g.Fail(fmt.Sprintf("can't remove temporary directory: %v", err))
+		}
+	})
+	return dir
+}
+
+// TempFile creates a temporary file, open for reading and writing, whose name
+// is generated from pattern as with os.CreateTemp. It's closed and removed
+// once the spec or block being run finishes, like with TempDir.
+func (g *G) TempFile(pattern string) *os.File {
+	file, err := os.CreateTemp(g.TempDir(), pattern)
+	if err != nil {
+		g.Fail(fmt.Sprintf("can't create a temporary file: %v", err))
+	}
+	g.Cleanup(func() {
+		file.Close()
+	})
+	return file
+}
@@ -0,0 +1,50 @@
+package goblin
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTempDir(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	var blockDir, specDir, file string
+	g.Describe("Temporary paths", func() {
+		g.Before(func() {
+			blockDir = g.TempDir()
+		})
+
+		g.It("Should create a directory for the spec", func() {
+			specDir = g.TempDir()
+			g.Assert(os.WriteFile(filepath.Join(specDir, "data"), []byte("x"), 0o644)).IsNil()
+			_, err := os.Stat(blockDir)
+			g.Assert(err).IsNil()
+		})
+
+		g.It("Should create a file for the spec", func() {
+			f := g.TempFile("*.json")
+			file = f.Name()
+			_, err := f.WriteString("{}")
+			g.Assert(err).IsNil()
+			g.Assert(filepath.Ext(file)).Equal(".json")
+			_, err = os.Stat(specDir)
+			g.Assert(errors.Is(err, fs.ErrNotExist)).IsTrue()
+		})
+	})
+
+	if len(reporter.passes) != 2 {
+		t.Fatalf("Failed: passes %v, fails %v", reporter.passes, reporter.fails)
+	}
+	for _, path := range []string{blockDir, specDir, file, filepath.Dir(file)} {
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Fatalf("Failed: %s wasn't removed: %v", path, err)
+		}
+	}
+}
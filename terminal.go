@@ -0,0 +1,108 @@
+package goblin
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// terminalWidth returns the number of columns output is wrapped to: COLUMNS if
+// set, otherwise the width of the terminal stdout is attached to, or 0 if it
+// isn't attached to one.
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return stdoutWidth()
+}
+
+// colorOutput returns whether output is colored unless -goblin.tty says
+// otherwise: never when NO_COLOR is set, always when FORCE_COLOR is set, and
+// otherwise when stdout is attached to a terminal.
+func colorOutput(terminal bool) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if os.Getenv("FORCE_COLOR") != "" {
+		return true
+	}
+	return terminal
+}
+
+// checkMark returns the glyph marking passing specs, unless limited to ASCII
+// with -goblin.unicode=false
+func checkMark() string {
+	if *unicodeOutput {
+		return "✓"
+	}
+	return "+"
+}
+
+// ellipsis returns the marker of truncated text
+func ellipsis() string {
+	if *unicodeOutput {
+		return "…"
+	}
+	return "..."
+}
+
+// visibleLen returns the number of columns text takes in a terminal, ignoring
+// color escape sequences.
+func visibleLen(text string) int {
+	n := 0
+	for i := 0; i < len(text); {
+		if text[i] == '\033' {
+			if end := strings.IndexByte(text[i:], 'm'); end >= 0 {
+				i += end + 1
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		n++
+	}
+	return n
+}
+
+// truncate shortens text to at most width columns, marking it as truncated
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	marker := ellipsis()
+	if width <= len(marker) {
+		return string(runes[:width])
+	}
+	return string(runes[:width-len([]rune(marker))]) + marker
+}
+
+// wrap splits text into lines of at most width columns, breaking at spaces
+// where possible. A width of 0 or less leaves the text as is.
+func wrap(text string, width int) []string {
+	if width <= 0 {
+		return []string{text}
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		runes := []rune(paragraph)
+		for len(runes) > width {
+			cut := width
+			for i := width; i > 0; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+			lines = append(lines, string(runes[:cut]))
+			runes = runes[cut:]
+			if len(runes) > 0 && runes[0] == ' ' {
+				runes = runes[1:]
+			}
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
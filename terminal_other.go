@@ -0,0 +1,9 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+
+package goblin
+
+// stdoutWidth returns 0 where the width of the terminal isn't detected, in
+// which case output is only wrapped when COLUMNS is set.
+func stdoutWidth() int {
+	return 0
+}
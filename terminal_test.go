@@ -0,0 +1,103 @@
+package goblin
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTruncate(t *testing.T) {
+	if s := truncate("Should fit", 10); s != "Should fit" {
+		t.Fatalf("Failed: %q", s)
+	}
+	if s := truncate("Should be truncated", 10); s != "Should be…" {
+		t.Fatalf("Failed: %q", s)
+	}
+
+	*unicodeOutput = false
+	defer func() {
+		*unicodeOutput = true
+	}()
+	if s := truncate("Should be truncated", 10); s != "Should ..." {
+		t.Fatalf("Failed: %q", s)
+	}
+	if checkMark() != "+" {
+		t.Fatalf("Failed: check mark %q", checkMark())
+	}
+}
+
+func TestWrap(t *testing.T) {
+	lines := wrap("expected 1 to equal 2\nbut it didn't", 10)
+	expected := []string{"expected 1", "to equal 2", "but it", "didn't"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("Failed: %q", lines)
+	}
+	if lines := wrap("abcdefghijkl", 5); !reflect.DeepEqual(lines, []string{"abcde", "fghij", "kl"}) {
+		t.Fatalf("Failed: %q", lines)
+	}
+	if lines := wrap("unchanged", 0); !reflect.DeepEqual(lines, []string{"unchanged"}) {
+		t.Fatalf("Failed: %q", lines)
+	}
+}
+
+func TestColorOutput(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	if !colorOutput(true) || colorOutput(false) {
+		t.Fatalf("Failed: color should follow the terminal")
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+	if !colorOutput(false) {
+		t.Fatalf("Failed: FORCE_COLOR should color output")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if colorOutput(true) {
+		t.Fatalf("Failed: NO_COLOR should turn off colors")
+	}
+}
+
+func TestVisibleLen(t *testing.T) {
+	if n := visibleLen((&TerminalFancier{}).WithCheck("abc")); n != 5 {
+		t.Fatalf("Failed: %d", n)
+	}
+}
+
+func TestReportingWidth(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() {
+		os.Stdout = stdout
+	}()
+
+	reporter := &DetailedReporter{fancy: &Monochrome{}, width: 30}
+	reporter.BeginDescribe("Numbers")
+	reporter.ItTook(12 * time.Millisecond)
+	reporter.ItPassed("Should add")
+	reporter.ItTook(3 * time.Millisecond)
+	reporter.ItPassed("Should subtract numbers of any size")
+	reporter.ItIsPending("Should multiply numbers of any size")
+	reporter.EndDescribe()
+	w.Close()
+	output, _ := io.ReadAll(r)
+
+	expected := []string{
+		"",
+		"  Numbers",
+		"    >>>Should add         12ms",
+		"    >>>Should subtract nu… 3ms",
+		"    - Should multiply numbers…",
+	}
+	lines := strings.Split(strings.TrimRight(string(output), "\n"), "\n")
+	if !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("Failed: output\n%s", strings.Join(lines, "\n"))
+	}
+}
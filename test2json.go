@@ -0,0 +1,180 @@
+package goblin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// test2jsonEvent is an event in the format of go test -json, with the location
+// of the spec added for test explorers
+type test2jsonEvent struct {
+	Time    time.Time `json:",omitempty"`
+	Action  string
+	Test    string   `json:",omitempty"`
+	Elapsed *float64 `json:",omitempty"`
+	Output  string   `json:",omitempty"`
+	ID      string   `json:",omitempty"`
+	File    string   `json:",omitempty"`
+	Line    int      `json:",omitempty"`
+	Owner   string   `json:",omitempty"`
+	Links   []string `json:",omitempty"`
+}
+
+// test2jsonFiles are the -goblin.test2json files opened, by path, shared by
+// the suites of every Go test of the package
+var (
+	test2jsonFilesMu sync.Mutex
+	test2jsonFiles   = map[string]*os.File{}
+)
+
+// openTest2JSONFile returns the -goblin.test2json file at path, created the
+// first time a suite writes to it
+func openTest2JSONFile(path string) (io.Writer, error) {
+	test2jsonFilesMu.Lock()
+	defer test2jsonFilesMu.Unlock()
+	if f := test2jsonFiles[path]; f != nil {
+		return f, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	test2jsonFiles[path] = f
+	return f, nil
+}
+
+// Test2JSONReporter writes an event for each spec in the format of
+// go test -json, so tools consuming that format, such as editor test
+// explorers and gotestsum, show every spec as a subtest of the Go test running
+// the suite. Enabled with -goblin.test2json, alongside the usual output: the
+// events are written to a file since go test -json would wrap them as output
+// of the Go test.
+type Test2JSONReporter struct {
+	w         io.Writer
+	test      string
+	mu        sync.Mutex // Guards the fields below, and the writes to w
+	describes []string
+	running   string // Test name of the running spec
+	notRun    bool   // Whether the running spec is a hook which failed without running
+}
+
+// NewTest2JSONReporter creates a Test2JSONReporter writing to w, for the suite
+// run by the Go test named test.
+func NewTest2JSONReporter(w io.Writer, test string) *Test2JSONReporter {
+	return &Test2JSONReporter{w: w, test: test}
+}
+
+// testName returns the name of the subtest for the spec at path
+func (r *Test2JSONReporter) testName(path []string) string {
+	names := append([]string{r.test}, path...)
+	for i, name := range names {
+		names[i] = strings.Join(strings.Fields(name), "_")
+	}
+	return strings.Join(names, "/")
+}
+
+// emit writes the event e, with r.mu held
+func (r *Test2JSONReporter) emit(e test2jsonEvent) {
+	e.Time = time.Now()
+	data, _ := json.Marshal(e)
+	r.w.Write(append(data, '\n'))
+}
+
+// skip reports a spec which doesn't run
+func (r *Test2JSONReporter) skip(name, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	test := r.testName(append(append([]string(nil), r.describes...), name))
+	r.emit(test2jsonEvent{Action: "run", Test: test})
+	r.emit(test2jsonEvent{Action: "output", Test: test, Output: fmt.Sprintf("--- SKIP: %s (%s)\n", test, reason)})
+	elapsed := 0.0
+	r.emit(test2jsonEvent{Action: "skip", Test: test, Elapsed: &elapsed})
+}
+
+func (r *Test2JSONReporter) SpecStarted(report *SpecReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.running = r.testName(report.Path)
+	r.emit(test2jsonEvent{Action: "run", Test: r.running, ID: report.ID, File: report.File, Line: report.Line,
+		Owner: report.Owner, Links: report.Links})
+}
+
+func (r *Test2JSONReporter) SpecDone(report *SpecReport) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	action, status := "pass", "PASS"
+	if report.Failed {
+		action, status = "fail", "FAIL"
+	}
+	elapsed := report.Duration.Seconds()
+	r.emit(test2jsonEvent{Action: "output", Test: r.running, Output: fmt.Sprintf("--- %s: %s (%.2fs)\n", status, r.running, elapsed)})
+	r.emit(test2jsonEvent{Action: action, Test: r.running, Elapsed: &elapsed})
+	r.running = ""
+}
+
+func (r *Test2JSONReporter) ItFailed(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running == "" {
+		// Failed Before and After hooks are reported without running
+		r.running = r.testName(append(append([]string(nil), r.describes...), name))
+		r.notRun = true
+		r.emit(test2jsonEvent{Action: "run", Test: r.running})
+	}
+}
+
+func (r *Test2JSONReporter) Failure(failure *Failure) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lines := append([]string{formatFailure(failure)}, failure.Stack...)
+	for _, additional := range failure.Additional {
+		lines = append(append(lines, "Also: "+formatFailure(additional)), additional.Stack...)
+	}
+	if failure.Output != "" {
+		lines = append(lines, strings.Split(strings.TrimRight(failure.Output, "\n"), "\n")...)
+	}
+	for _, line := range lines {
+		r.emit(test2jsonEvent{Action: "output", Test: r.running, Output: "    " + strings.TrimSpace(line) + "\n"})
+	}
+
+	if r.notRun {
+		elapsed := 0.0
+		r.emit(test2jsonEvent{Action: "output", Test: r.running, Output: fmt.Sprintf("--- FAIL: %s (0.00s)\n", r.running)})
+		r.emit(test2jsonEvent{Action: "fail", Test: r.running, Elapsed: &elapsed})
+		r.running, r.notRun = "", false
+	}
+}
+
+func (r *Test2JSONReporter) BeginDescribe(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.describes = append(r.describes, name)
+}
+
+func (r *Test2JSONReporter) EndDescribe() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.describes = r.describes[:len(r.describes)-1]
+}
+
+func (r *Test2JSONReporter) ItIsPending(name string) {
+	r.skip(name, "pending")
+}
+
+func (r *Test2JSONReporter) ItIsExcluded(name string) {
+	r.skip(name, "excluded")
+}
+
+func (r *Test2JSONReporter) ItSkipped(name, reason string) {
+	r.skip(name, reason)
+}
+
+func (r *Test2JSONReporter) Begin()               {}
+func (r *Test2JSONReporter) End()                 {}
+func (r *Test2JSONReporter) ItTook(time.Duration) {}
+func (r *Test2JSONReporter) ItPassed(name string) {}
@@ -0,0 +1,120 @@
+package goblin
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTest2JSONReporter(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewTest2JSONReporter(&out, "TestSuite")))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.Describe("Nested", func() {
+			g.It("Should fail", func() {
+				g.Fail("failed")
+			}, Timeout(time.Second))
+		})
+		g.It("Should be pending")
+	})
+
+	var actions []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var e test2jsonEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Action == "run" && e.Test == "TestSuite/Numbers/Should_add" && !strings.HasSuffix(e.File, "test2json_test.go") {
+			t.Fatalf("Failed: location %s:%d", e.File, e.Line)
+		}
+		if e.Action != "output" {
+			actions = append(actions, e.Action+" "+e.Test)
+		}
+	}
+
+	expected := []string{
+		"run TestSuite/Numbers/Should_add",
+		"pass TestSuite/Numbers/Should_add",
+		"run TestSuite/Numbers/Nested/Should_fail",
+		"fail TestSuite/Numbers/Nested/Should_fail",
+		"run TestSuite/Numbers/Should_be_pending",
+		"skip TestSuite/Numbers/Should_be_pending",
+	}
+	if strings.Join(actions, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("Failed: events\n%s", strings.Join(actions, "\n"))
+	}
+	if !strings.Contains(out.String(), `"Output":"    failed\n"`) {
+		t.Fatalf("Failed: output\n%s", out.String())
+	}
+}
+
+func TestTest2JSONReporterHookFailure(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewTest2JSONReporter(&out, "TestSuite")))
+
+	g.Describe("Numbers", func() {
+		g.After(func() {
+			g.Fail("could not clean up")
+		})
+		g.It("Should add", func() {}, Timeout(time.Second))
+	})
+
+	if !strings.Contains(out.String(), `"Action":"fail","Test":"TestSuite/Numbers/\"after_all\"_hook"`) {
+		t.Fatalf("Failed: output\n%s", out.String())
+	}
+}
+
+func TestTest2JSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	*test2jsonFile = path
+	defer func() { *test2jsonFile = "" }()
+
+	reporter := FakeReporter{}
+	for _, test := range []string{"TestFirst", "TestSecond"} {
+		t.Run(test, func(t *testing.T) {
+			g := Goblin(t, WithParallel(3))
+			g.SetReporter(Reporter(&reporter))
+			g.Describe("Numbers", func() {
+				for _, name := range []string{"Should add", "Should subtract", "Should multiply"} {
+					g.It(name, func() {
+						time.Sleep(10 * time.Millisecond)
+					}, Timeout(time.Second))
+				}
+			})
+		})
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	passed := 0
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var e test2jsonEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("Failed: %v", err)
+		}
+		if e.Action == "pass" {
+			passed++
+		}
+	}
+	// Reported to the file as well as to the reporter which was set
+	if passed != 6 || len(reporter.passes) != 6 {
+		t.Fatalf("Failed: %d passed in\n%s", passed, data)
+	}
+	if !strings.Contains(string(data), `"Action":"pass","Test":"TestTest2JSONFile/TestSecond/Numbers/Should_add"`) {
+		t.Fatalf("Failed: events\n%s", data)
+	}
+}
@@ -0,0 +1,64 @@
+package goblin
+
+// Theme colors the output of the DetailedReporter, with a Select Graphic
+// Rendition parameter for each kind of text, e.g. "31" for red or "1;91" for
+// bold bright red. Text whose parameter is empty is left as is. Pass a Theme
+// to NewDetailedReporter or SetTextFancier, or pick a built-in one with
+// -goblin.theme.
+type Theme struct {
+	Pass     string // Check marks and the number of passing specs
+	Fail     string // Failing specs and failure messages
+	Pending  string
+	Excluded string // Excluded and skipped specs, expected failures and warnings
+	Detail   string // Durations, stack traces and the output of specs
+}
+
+var (
+	// DefaultTheme uses the standard colors of the terminal.
+	DefaultTheme = &Theme{Pass: "32", Fail: "31", Pending: "36", Excluded: "33", Detail: "90"}
+	// HighContrastTheme uses bold, bright colors, e.g. for dark backgrounds or
+	// low contrast displays.
+	HighContrastTheme = &Theme{Pass: "1;92", Fail: "1;91", Pending: "1;96", Excluded: "1;93", Detail: "97"}
+	// DimTheme only colors what needs attention, leaving passing specs and
+	// details in the default color of the terminal.
+	DimTheme = &Theme{Fail: "31", Pending: "2;36", Excluded: "2;33"}
+)
+
+// themes are the built-in themes, by their name for -goblin.theme
+var themes = map[string]*Theme{
+	"default":       DefaultTheme,
+	"high-contrast": HighContrastTheme,
+	"dim":           DimTheme,
+}
+
+// paint wraps text in the escape sequences of the SGR parameter code
+func paint(code, text string) string {
+	if code == "" {
+		return text
+	}
+	return "\033[" + code + "m" + text + "\033[0m"
+}
+
+func (t *Theme) Red(text string) string {
+	return paint(t.Fail, text)
+}
+
+func (t *Theme) Gray(text string) string {
+	return paint(t.Detail, text)
+}
+
+func (t *Theme) Cyan(text string) string {
+	return paint(t.Pending, text)
+}
+
+func (t *Theme) Green(text string) string {
+	return paint(t.Pass, text)
+}
+
+func (t *Theme) Yellow(text string) string {
+	return paint(t.Excluded, text)
+}
+
+func (t *Theme) WithCheck(text string) string {
+	return paint(t.Pass, checkMark()) + " " + text
+}
@@ -0,0 +1,47 @@
+package goblin
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTheme(t *testing.T) {
+	fakeTest := testing.T{}
+	var out bytes.Buffer
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(NewDetailedReporter(&out, &Theme{Pass: "1;32", Fail: "35"})))
+
+	g.Describe("Numbers", func() {
+		g.It("Should add", func() {}, Timeout(time.Second))
+		g.It("Should subtract", func() {
+			g.Fail("failed")
+		}, Timeout(time.Second))
+		g.It("Should multiply")
+	})
+
+	for _, expected := range []string{
+		"\033[1;32m✓\033[0m Should add\n",
+		"\033[35m1) Should subtract\033[0m\n",
+		"  - Should multiply\n",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Fatalf("Failed: %q in output\n%q", expected, out.String())
+		}
+	}
+}
+
+func TestTerminalFancierTheme(t *testing.T) {
+	fancier := &TerminalFancier{}
+	if text := fancier.Red("failed"); text != "\033[31mfailed\033[0m" {
+		t.Fatalf("Failed: %q", text)
+	}
+	if text := HighContrastTheme.Red("failed"); text != "\033[1;91mfailed\033[0m" {
+		t.Fatalf("Failed: %q", text)
+	}
+	if text := DimTheme.Gray("12ms"); text != "12ms" {
+		t.Fatalf("Failed: %q", text)
+	}
+}
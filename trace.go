@@ -0,0 +1,76 @@
+package goblin
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// timeline records when Describe blocks, tests and hooks ran, if enabled with
+// -goblin.trace. It is shared by every suite of the test binary.
+var timeline *tracer
+
+// traceEvent is a complete event of the Chrome trace event format, which can be
+// viewed with Perfetto or chrome://tracing
+type traceEvent struct {
+	Name      string `json:"name"`
+	Category  string `json:"cat"`
+	Phase     string `json:"ph"`
+	Timestamp int64  `json:"ts"`  // Microseconds since the trace started
+	Duration  int64  `json:"dur"` // Microseconds
+	Pid       int    `json:"pid"`
+	Tid       int    `json:"tid"`
+}
+
+// tracer records trace events. A nil tracer records nothing.
+type tracer struct {
+	mu     sync.Mutex
+	start  time.Time
+	events []traceEvent
+}
+
+func newTracer() *tracer {
+	return &tracer{start: time.Now()}
+}
+
+// begin starts an event on the track tid, returning the function which ends
+// it. Each shard of the suite has its own track, so specs running in parallel
+// don't overlap.
+func (t *tracer) begin(tid int, category, name string) (end func()) {
+	if t == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.events = append(t.events, traceEvent{
+			Name:      name,
+			Category:  category,
+			Phase:     "X",
+			Timestamp: start.Sub(t.start).Microseconds(),
+			Duration:  time.Since(start).Microseconds(),
+			Pid:       os.Getpid(),
+			Tid:       tid,
+		})
+	}
+}
+
+// write writes the events recorded so far to path as a JSON trace
+func (t *tracer) write(path string) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data, err := json.Marshal(struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}{t.events})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
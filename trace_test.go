@@ -0,0 +1,102 @@
+package goblin
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTrace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	timeline = newTracer()
+	*traceFile = path
+	defer func() {
+		timeline = nil
+		*traceFile = ""
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	g.Describe("Trace", func() {
+		g.Before(func() {})
+		g.BeforeEach(func() {})
+		g.It("Should be recorded", func() {})
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(data, &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, e := range trace.TraceEvents {
+		if e.Phase != "X" || e.Pid != os.Getpid() {
+			t.Fatalf("Failed: event %+v", e)
+		}
+		names = append(names, e.Category+": "+e.Name)
+	}
+	expected := []string{
+		`hook: Trace "before all" hook`,
+		`hook: Trace "before each" hook`,
+		"test: Trace Should be recorded",
+		"describe: Trace",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Failed: events %v", names)
+	}
+}
+
+func TestTraceParallel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	timeline = newTracer()
+	*traceFile = path
+	defer func() {
+		timeline = nil
+		*traceFile = ""
+	}()
+
+	fakeTest := testing.T{}
+	g := Goblin(&fakeTest, WithParallel(2))
+	g.SetReporter(Reporter(&FakeReporter{}))
+
+	g.Describe("Trace", func() {
+		g.It("Should be recorded on a track", func() {
+			time.Sleep(20 * time.Millisecond)
+		}, Timeout(time.Second))
+		g.It("Should be recorded on another track", func() {
+			time.Sleep(20 * time.Millisecond)
+		}, Timeout(time.Second))
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(data, &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	tids := map[int]bool{}
+	for _, e := range trace.TraceEvents {
+		if e.Category == "test" {
+			tids[e.Tid] = true
+		}
+	}
+	if !reflect.DeepEqual(tids, map[int]bool{1: true, 2: true}) {
+		t.Fatalf("Failed: tracks %v", tids)
+	}
+}
@@ -0,0 +1,60 @@
+package goblin
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// waitGroup counts the signals received by a Done returned by WaitGroup
+type waitGroup struct {
+	expected int32
+	received int32
+}
+
+// WaitGroup returns a Done for async specs whose work is spread across several
+// goroutines. Each of them calls the returned Done once, and the spec
+// completes once all n have. A spec timing out reports how many signals were
+// still outstanding. WaitGroup can only be used in a spec taking a Done.
+//
+//	g.It("Should notify every subscriber", func(done goblin.Done) {
+//		signal := g.WaitGroup(len(subscribers))
+//		for _, s := range subscribers {
+//			go s.Wait(signal)
+//		}
+//		...
+//	})
+func (g *G) WaitGroup(n int) Done {
+	g = g.active()
+	wg := &waitGroup{expected: int32(n)}
+	g.mutex.Lock()
+	done := g.done
+	g.waiting = wg
+	g.mutex.Unlock()
+	if done == nil {
+		g.Fail("WaitGroup can only be used in a spec taking a Done")
+	}
+
+	return func(msg ...interface{}) {
+		if len(msg) > 0 {
+			done(msg...)
+			return
+		}
+		// Signals beyond n are passed on so they're reported as extra calls
+		if atomic.AddInt32(&wg.received, 1) >= wg.expected {
+			done()
+		}
+	}
+}
+
+// outstandingSignals describes the signals the running spec still waits for,
+// if any
+func (g *G) outstandingSignals() string {
+	g.mutex.Lock()
+	wg := g.waiting
+	g.mutex.Unlock()
+	if wg == nil {
+		return ""
+	}
+	outstanding := wg.expected - atomic.LoadInt32(&wg.received)
+	return fmt.Sprintf("%d of %d signals still outstanding", outstanding, wg.expected)
+}
@@ -0,0 +1,53 @@
+package goblin
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitGroup(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := outputReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Signals", func() {
+		g.It("Should wait for every goroutine", func(done Done) {
+			signal := g.WaitGroup(3)
+			for i := 0; i < 3; i++ {
+				go signal()
+			}
+		}, Timeout(time.Second))
+		g.It("Should report outstanding signals", func(done Done) {
+			signal := g.WaitGroup(3)
+			go signal()
+		}, Timeout(50*time.Millisecond))
+	})
+
+	if len(reporter.passes) != 1 || reporter.passes[0] != "Should wait for every goroutine" {
+		t.Fatalf("Failed: passes %v", reporter.passes)
+	}
+	if len(reporter.captured) != 1 || !strings.HasSuffix(reporter.captured[0].Message, "2 of 3 signals still outstanding") {
+		t.Fatalf("Failed: failures %+v", reporter.captured)
+	}
+}
+
+func TestWaitGroupSync(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Signals", func() {
+		g.It("Should need a Done", func() {
+			g.WaitGroup(1)
+		}, Timeout(time.Second))
+	})
+
+	if len(reporter.fails) != 1 {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
@@ -0,0 +1,69 @@
+package goblin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WarningReporter is implemented by reporters which report the warnings
+// recorded by specs with Warn, before the spec passes or fails.
+type WarningReporter interface {
+	ItWarned(name string, warnings []string)
+}
+
+// Warn records a warning against the running spec without failing it, e.g. to
+// flag use of a deprecated fixture. Warnings are listed in the summary of the
+// run, and fail the spec with -goblin.warnings-as-errors. The arguments are
+// formatted as with fmt.Sprint.
+func (g *G) Warn(args ...interface{}) {
+	msg := fmt.Sprint(args...)
+	current, _ := g.active().current()
+	it, ok := current.(*It)
+	if !ok {
+		fmt.Printf("goblin: warning: %s\n", msg)
+		return
+	}
+
+	it.failureMu.Lock()
+	defer it.failureMu.Unlock()
+	it.warnings = append(it.warnings, msg)
+}
+
+// checkBudget fails the spec, or warns with -goblin.budgets-as-warnings, if it
+// took longer than its budget. It returns whether the spec failed.
+func (it *It) checkBudget(duration time.Duration, failed bool) bool {
+	if it.budget <= 0 || duration <= it.budget || failed {
+		return failed
+	}
+
+	msg := fmt.Sprintf("took %s, over its budget of %s", duration, it.budget)
+	if *budgetsAsWarnings {
+		it.failureMu.Lock()
+		defer it.failureMu.Unlock()
+		it.warnings = append(it.warnings, msg)
+		return false
+	}
+	it.failed(msg, []string{it.location.String()})
+	return true
+}
+
+// reportWarnings reports the warnings recorded by the spec, failing it if
+// warnings are treated as errors. It returns whether the spec failed.
+func (it *It) reportWarnings(g *G, failed bool) bool {
+	it.failureMu.RLock()
+	warnings := append([]string(nil), it.warnings...)
+	it.failureMu.RUnlock()
+	if len(warnings) == 0 {
+		return failed
+	}
+
+	if r, ok := g.reporter.(WarningReporter); ok {
+		r.ItWarned(it.name, warnings)
+	}
+	if *warningsAsErrors && !failed {
+		it.failed("warnings are treated as errors: "+strings.Join(warnings, "; "), []string{it.location.String()})
+		return true
+	}
+	return failed
+}
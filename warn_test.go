@@ -0,0 +1,69 @@
+package goblin
+
+import (
+	"reflect"
+	"testing"
+)
+
+type warningReporter struct {
+	specReporter
+	warned map[string][]string
+}
+
+func (r *warningReporter) ItWarned(name string, warnings []string) {
+	if r.warned == nil {
+		r.warned = map[string][]string{}
+	}
+	r.warned[name] = warnings
+}
+
+func TestWarn(t *testing.T) {
+	fakeTest := testing.T{}
+	reporter := warningReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Warnings", func() {
+		g.It("Should warn", func() {
+			g.Warn("deprecated fixture")
+			g.Warn("slow ", "setup")
+		})
+		g.It("Should not warn", func() {})
+	})
+
+	if fakeTest.Failed() || len(reporter.fails) != 0 {
+		t.Fatal("Failed: warnings shouldn't fail the spec")
+	}
+	expected := map[string][]string{"Should warn": {"deprecated fixture", "slow setup"}}
+	if !reflect.DeepEqual(reporter.warned, expected) {
+		t.Fatalf("Failed: warnings %v", reporter.warned)
+	}
+	if !reflect.DeepEqual(reporter.reports[0].Warnings, expected["Should warn"]) || reporter.reports[1].Warnings != nil {
+		t.Fatalf("Failed: reported warnings %v, %v", reporter.reports[0].Warnings, reporter.reports[1].Warnings)
+	}
+}
+
+func TestWarningsAsErrors(t *testing.T) {
+	*warningsAsErrors = true
+	defer func() {
+		*warningsAsErrors = false
+	}()
+
+	fakeTest := testing.T{}
+	reporter := FakeReporter{}
+
+	g := Goblin(&fakeTest)
+	g.SetReporter(Reporter(&reporter))
+
+	g.Describe("Warnings", func() {
+		g.It("Should fail", func() {
+			g.Warn("deprecated fixture")
+		})
+		g.It("Should pass", func() {})
+	})
+
+	if !fakeTest.Failed() || !reflect.DeepEqual(reporter.fails, []string{"Should fail"}) {
+		t.Fatalf("Failed: fails %v", reporter.fails)
+	}
+}
@@ -0,0 +1,161 @@
+package goblin
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// XMLEqual asserts that the source and destination, XML documents given as a
+// []byte or string, are equivalent. Both are canonicalized before being
+// compared: whitespace between elements, the order of attributes and the
+// prefixes bound to namespaces don't matter. A failure reports the XPath of
+// the first node which differs.
+func (a *Assertion) XMLEqual(dst interface{}, messages ...interface{}) {
+	src, err := parseXMLDocument(a.src)
+	if err != nil {
+		a.fail(fmt.Sprintf("%#v is not an XML document: %v%s", a.src, err, formatMessages(messages...)))
+		return
+	}
+	expected, err := parseXMLDocument(dst)
+	if err != nil {
+		a.fail(fmt.Sprintf("%#v is not an XML document: %v%s", dst, err, formatMessages(messages...)))
+		return
+	}
+
+	if diff := src.diff(expected, ""); diff != "" {
+		a.fail(fmt.Sprintf("XML documents differ at %s%s", diff, formatMessages(messages...)))
+	}
+}
+
+// xmlNode is a canonical XML element
+type xmlNode struct {
+	name     xml.Name
+	attrs    []xml.Attr // Sorted, without namespace declarations
+	text     string     // Trimmed text, for elements without child elements
+	children []*xmlNode
+}
+
+// parseXMLDocument parses the root element of doc
+func parseXMLDocument(doc interface{}) (*xmlNode, error) {
+	var data []byte
+	switch d := doc.(type) {
+	case []byte:
+		data = d
+	case string:
+		data = []byte(d)
+	default:
+		return nil, fmt.Errorf("expected a []byte or string")
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*xmlNode
+	var root *xmlNode
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name}
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					continue
+				}
+				node.attrs = append(node.attrs, attr)
+			}
+			sort.Slice(node.attrs, func(i, j int) bool {
+				if node.attrs[i].Name.Space != node.attrs[j].Name.Space {
+					return node.attrs[i].Name.Space < node.attrs[j].Name.Space
+				}
+				return node.attrs[i].Name.Local < node.attrs[j].Name.Local
+			})
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			}
+			stack = append(stack, node)
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			if len(node.children) == 0 {
+				node.text = strings.TrimSpace(text.String())
+			}
+			stack = stack[:len(stack)-1]
+			text.Reset()
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no root element")
+	}
+	return root, nil
+}
+
+// xmlName displays a name with its namespace URI, if any
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return "{" + name.Space + "}" + name.Local
+}
+
+// diff returns the XPath of the first node differing between n and o, and how
+// they differ, or an empty string if they are equivalent. The path is the
+// XPath of the parent of n.
+func (n *xmlNode) diff(o *xmlNode, path string) string {
+	if n.name != o.name {
+		return fmt.Sprintf("%s/%s: element %s, expected %s", path, xmlName(n.name), xmlName(n.name), xmlName(o.name))
+	}
+	path += "/" + xmlName(n.name)
+
+	for i := 0; i < len(n.attrs) || i < len(o.attrs); i++ {
+		switch {
+		case i >= len(o.attrs):
+			return fmt.Sprintf("%s/@%s: unexpected attribute", path, xmlName(n.attrs[i].Name))
+		case i >= len(n.attrs) || n.attrs[i].Name != o.attrs[i].Name:
+			return fmt.Sprintf("%s/@%s: missing attribute", path, xmlName(o.attrs[i].Name))
+		case n.attrs[i].Value != o.attrs[i].Value:
+			return fmt.Sprintf("%s/@%s: %q, expected %q", path, xmlName(n.attrs[i].Name), n.attrs[i].Value, o.attrs[i].Value)
+		}
+	}
+
+	if n.text != o.text {
+		return fmt.Sprintf("%s/text(): %q, expected %q", path, n.text, o.text)
+	}
+
+	// Children are indexed among the siblings sharing their name
+	seen := map[xml.Name]int{}
+	for i := 0; i < len(n.children) || i < len(o.children); i++ {
+		switch {
+		case i >= len(o.children):
+			child := n.children[i]
+			seen[child.name]++
+			return fmt.Sprintf("%s/%s[%d]: unexpected element", path, xmlName(child.name), seen[child.name])
+		case i >= len(n.children):
+			child := o.children[i]
+			seen[child.name]++
+			return fmt.Sprintf("%s/%s[%d]: missing element", path, xmlName(child.name), seen[child.name])
+		}
+		child := n.children[i]
+		seen[child.name]++
+		if diff := child.diff(o.children[i], path); diff != "" {
+			// Qualify the child's step with its index
+			step := "/" + xmlName(child.name)
+			return path + fmt.Sprintf("%s[%d]", step, seen[child.name]) + strings.TrimPrefix(diff, path+step)
+		}
+	}
+	return ""
+}
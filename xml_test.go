@@ -0,0 +1,35 @@
+package goblin
+
+import (
+	"testing"
+)
+
+func TestXMLEqual(t *testing.T) {
+	verifier := AssertionVerifier{ShouldPass: true}
+	a := Assertion{src: `<a:order xmlns:a="urn:orders" id="1" status="new">
+		<a:item sku="x">  2 </a:item>
+	</a:order>`, fail: verifier.FailFunc}
+	a.XMLEqual(`<order xmlns="urn:orders" status="new" id="1"><item sku="x">2</item></order>`)
+	verifier.Verify(t)
+}
+
+func TestXMLEqualDifferences(t *testing.T) {
+	for dst, message := range map[string]string{
+		`<order id="2"><item>1</item><item>2</item></order>`: `XML documents differ at /order/@id: "1", expected "2"`,
+		`<order id="1"><item>1</item><item>3</item></order>`: `XML documents differ at /order/item[2]/text(): "2", expected "3"`,
+		`<order id="1"><item>1</item></order>`:               `XML documents differ at /order/item[2]: unexpected element`,
+		`<order><item>1</item><item>2</item></order>`:        `XML documents differ at /order/@id: unexpected attribute`,
+		`<invoice id="1"/>`:                                  `XML documents differ at /order: element order, expected invoice`,
+		`<order xmlns="urn:x" id="1"/>`:                      `XML documents differ at /order: element order, expected {urn:x}order`,
+	} {
+		verifier := AssertionVerifier{ShouldPass: false}
+		a := Assertion{src: `<order id="1"><item>1</item><item>2</item></order>`, fail: verifier.FailFunc}
+		a.XMLEqual(dst)
+		verifier.VerifyMessage(t, message)
+	}
+
+	verifier := AssertionVerifier{ShouldPass: false}
+	a := Assertion{src: []byte("<order>"), fail: verifier.FailFunc}
+	a.XMLEqual("<order/>", "bad")
+	verifier.VerifyMessage(t, `[]byte{0x3c, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x3e} is not an XML document: XML syntax error on line 1: unexpected EOF, bad`)
+}